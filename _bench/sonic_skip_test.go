@@ -27,3 +27,8 @@ func sonicDecodeSmall(b *testing.B) {
 	b.Helper()
 	sonicSkip(b)
 }
+
+func sonicValidate(b *testing.B, _ []byte) {
+	b.Helper()
+	sonicSkip(b)
+}
@@ -41,3 +41,11 @@ func sonicDecodeSmall(b *testing.B) {
 		}
 	}
 }
+
+func sonicValidate(b *testing.B, data []byte) {
+	for i := 0; i < b.N; i++ {
+		if !sonic.Valid(data) {
+			b.Fatal("invalid")
+		}
+	}
+}
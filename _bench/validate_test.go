@@ -0,0 +1,81 @@
+package bench
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/romshark/jscan"
+
+	"github.com/go-faster/jx"
+)
+
+// setupValidate reads file from testdata of jx.
+func setupValidate(b *testing.B, file string) []byte {
+	b.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", file))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	return data
+}
+
+func BenchmarkValidate(b *testing.B) {
+	for _, file := range []string{
+		"twitter.json",
+		"citm_catalog.json",
+		"canada.json",
+	} {
+		file := file
+		b.Run(file, func(b *testing.B) {
+			b.Run(JX, func(b *testing.B) {
+				data := setupValidate(b, file)
+				var d jx.Decoder
+				for i := 0; i < b.N; i++ {
+					d.ResetBytes(data)
+					if err := d.Validate(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run(JX+"-utf8", func(b *testing.B) {
+				data := setupValidate(b, file)
+				var d jx.Decoder
+				d.SetUTF8Mode(jx.UTF8Strict)
+				for i := 0; i < b.N; i++ {
+					d.ResetBytes(data)
+					if err := d.Validate(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run(Std, func(b *testing.B) {
+				data := setupValidate(b, file)
+				for i := 0; i < b.N; i++ {
+					if !json.Valid(data) {
+						b.Fatal("invalid")
+					}
+				}
+			})
+			b.Run(Sonic, func(b *testing.B) {
+				sonicValidate(b, setupValidate(b, file))
+			})
+			b.Run(JScan, func(b *testing.B) {
+				data := string(setupValidate(b, file))
+				for i := 0; i < b.N; i++ {
+					r := jscan.Scan(
+						jscan.Options{},
+						data,
+						func(i *jscan.Iterator) bool { return false },
+					)
+					if r.IsErr() {
+						b.Fatal("err")
+					}
+				}
+			})
+		})
+	}
+}
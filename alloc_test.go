@@ -115,5 +115,15 @@ func TestZeroAlloc(t *testing.T) {
 		t.Run("Callback", func(t *testing.T) {
 			zeroAllocEnc(t, encodeSmallCallback)
 		})
+		t.Run("Str", func(t *testing.T) {
+			const s = "long enough string to use vectorized scanning\n"
+			b := []byte(s)
+			zeroAllocEnc(t, func(e *Encoder) {
+				e.Str(s)
+				e.StrEscape(s)
+				e.ByteStr(b)
+				e.ByteStrEscape(b)
+			})
+		})
 	})
 }
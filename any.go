@@ -0,0 +1,266 @@
+package jx
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// AnyType is type of Any value.
+type AnyType byte
+
+// Possible types for Any.
+const (
+	AnyInvalid AnyType = iota
+	AnyStr
+	AnyNumber
+	AnyNull
+	AnyObj
+	AnyArr
+	AnyBool
+)
+
+func (t AnyType) String() string {
+	switch t {
+	case AnyStr:
+		return "string"
+	case AnyNumber:
+		return "number"
+	case AnyNull:
+		return "null"
+	case AnyObj:
+		return "object"
+	case AnyArr:
+		return "array"
+	case AnyBool:
+		return "bool"
+	default:
+		return "invalid"
+	}
+}
+
+// Any represents any json value as sum type.
+//
+// Order of object fields is preserved. Read does not reuse buffers of
+// value, so values copied from it are not changed by subsequent reads.
+type Any struct {
+	Type AnyType // zero value if AnyInvalid, can be AnyNull
+
+	Str    string // AnyStr
+	Bool   bool   // AnyBool
+	Number Num    // AnyNumber
+
+	// Key in object. Valid only if KeyValid.
+	Key string
+	// KeyValid denotes whether Any is element of object.
+	// Needed for representing Key that is blank.
+	//
+	// Can be true only for Child of AnyObj.
+	KeyValid bool
+
+	Child []Any // AnyArr or AnyObj
+}
+
+// Equal reports whether v is equal to b.
+func (v Any) Equal(b Any) bool {
+	if v.KeyValid != b.KeyValid || v.KeyValid && v.Key != b.Key {
+		return false
+	}
+	if v.Type != b.Type {
+		return false
+	}
+	switch v.Type {
+	case AnyNull, AnyInvalid:
+		return true
+	case AnyBool:
+		return v.Bool == b.Bool
+	case AnyStr:
+		return v.Str == b.Str
+	case AnyNumber:
+		return v.Number.Equal(b.Number)
+	}
+	if len(v.Child) != len(b.Child) {
+		return false
+	}
+	for i := range v.Child {
+		if !v.Child[i].Equal(b.Child[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reads Any value.
+func (d *Decoder) Any() (Any, error) {
+	var v Any
+	if err := v.Read(d); err != nil {
+		return Any{}, err
+	}
+	return v, nil
+}
+
+// Any encodes Any value.
+func (e *Encoder) Any(a Any) {
+	a.Write(e)
+}
+
+// Read Any value from Decoder, resetting v.
+func (v *Any) Read(d *Decoder) error {
+	v.Reset()
+	switch d.Next() {
+	case Invalid:
+		c, err := d.more()
+		if err != nil {
+			return err
+		}
+		return d.badToken(c, d.offset()-1)
+	case Number:
+		n, err := d.NumAppend(nil)
+		if err != nil {
+			return errors.Wrap(err, "number")
+		}
+		v.Number = n
+		v.Type = AnyNumber
+	case String:
+		s, err := d.Str()
+		if err != nil {
+			return errors.Wrap(err, "str")
+		}
+		v.Str = s
+		v.Type = AnyStr
+	case Null:
+		if err := d.Null(); err != nil {
+			return errors.Wrap(err, "null")
+		}
+		v.Type = AnyNull
+	case Bool:
+		b, err := d.Bool()
+		if err != nil {
+			return errors.Wrap(err, "bool")
+		}
+		v.Bool = b
+		v.Type = AnyBool
+	case Object:
+		v.Type = AnyObj
+		if err := d.Obj(func(r *Decoder, s string) error {
+			elem := v.child()
+			if err := elem.Read(r); err != nil {
+				return errors.Wrap(err, "elem")
+			}
+			elem.Key = s
+			elem.KeyValid = true
+			return nil
+		}); err != nil {
+			v.Type = AnyInvalid
+			return errors.Wrap(err, "obj")
+		}
+	case Array:
+		v.Type = AnyArr
+		if err := d.Arr(func(r *Decoder) error {
+			if err := v.child().Read(r); err != nil {
+				return errors.Wrap(err, "elem")
+			}
+			return nil
+		}); err != nil {
+			v.Type = AnyInvalid
+			return errors.Wrap(err, "array")
+		}
+	}
+	return nil
+}
+
+// child appends new child to v.
+func (v *Any) child() *Any {
+	v.Child = append(v.Child, Any{})
+	return &v.Child[len(v.Child)-1]
+}
+
+// Write json representation of Any to Encoder.
+func (v Any) Write(w *Encoder) {
+	if v.KeyValid {
+		w.FieldStart(v.Key)
+	}
+	switch v.Type {
+	case AnyStr:
+		w.Str(v.Str)
+	case AnyNumber:
+		w.Num(v.Number)
+	case AnyBool:
+		w.Bool(v.Bool)
+	case AnyNull:
+		w.Null()
+	case AnyArr:
+		w.ArrStart()
+		for _, c := range v.Child {
+			c.Write(w)
+		}
+		w.ArrEnd()
+	case AnyObj:
+		w.ObjStart()
+		for _, c := range v.Child {
+			c.Write(w)
+		}
+		w.ObjEnd()
+	}
+}
+
+// String returns human-readable representation of v, not json.
+func (v Any) String() string {
+	var b strings.Builder
+	v.appendString(&b)
+	return b.String()
+}
+
+func (v Any) appendString(b *strings.Builder) {
+	if v.KeyValid {
+		if v.Key == "" {
+			b.WriteString("<blank>")
+		}
+		b.WriteString(v.Key)
+		b.WriteString(": ")
+	}
+	switch v.Type {
+	case AnyStr:
+		b.WriteString(`'` + v.Str + `'`)
+	case AnyNumber:
+		b.WriteString(v.Number.String())
+	case AnyBool:
+		b.WriteString(strconv.FormatBool(v.Bool))
+	case AnyNull:
+		b.WriteString("null")
+	case AnyArr, AnyObj:
+		open, end := "[", "]"
+		if v.Type == AnyObj {
+			open, end = "{", "}"
+		}
+		b.WriteString(open)
+		for i, c := range v.Child {
+			if i != 0 {
+				b.WriteString(", ")
+			}
+			c.appendString(b)
+		}
+		b.WriteString(end)
+	default:
+		b.WriteString("<invalid>")
+	}
+}
+
+// Reset Any value to zero value.
+func (v *Any) Reset() {
+	*v = Any{}
+}
+
+// Obj calls f for any child that is field if v is AnyObj.
+func (v Any) Obj(f func(k string, v Any)) {
+	if v.Type != AnyObj {
+		return
+	}
+	for _, c := range v.Child {
+		if !c.KeyValid {
+			continue
+		}
+		f(c.Key, c)
+	}
+}
@@ -0,0 +1,177 @@
+package jx
+
+import (
+	"github.com/go-faster/errors"
+)
+
+// Get returns value addressed by RFC 6901 json pointer.
+//
+// Returns ErrPointerNotFound if there is no such value. If object has
+// duplicate keys, first one is used.
+func (v *Any) Get(ptr string) (*Any, error) {
+	refs, err := parsePointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		i, err := v.ref(ref)
+		if err != nil {
+			return nil, err
+		}
+		v = &v.Child[i]
+	}
+	return v, nil
+}
+
+// Set replaces value addressed by json pointer with val.
+//
+// Missing object field is appended to the object, and "-" array index
+// appends val to the array. Empty pointer replaces v itself.
+//
+// Value is not copied deeply, so val shares children with v.
+func (v *Any) Set(ptr string, val Any) error {
+	if ptr == "" {
+		val.Key, val.KeyValid = v.Key, v.KeyValid
+		*v = val
+		return nil
+	}
+	p, ref, err := v.parent(ptr)
+	if err != nil {
+		return err
+	}
+	switch {
+	case p.Type == AnyObj:
+		val.Key, val.KeyValid = ref, true
+		if i, ok := p.field(ref); ok {
+			p.Child[i] = val
+			return nil
+		}
+	case p.Type == AnyArr && ref == "-":
+		val.Key, val.KeyValid = "", false
+	default:
+		i, err := p.ref(ref)
+		if err != nil {
+			return err
+		}
+		val.Key, val.KeyValid = "", false
+		p.Child[i] = val
+		return nil
+	}
+	p.Child = append(p.Child, val)
+	return nil
+}
+
+// Insert inserts val into array before element addressed by json pointer,
+// shifting subsequent elements. Index equal to array length or "-" appends
+// val to the array.
+func (v *Any) Insert(ptr string, val Any) error {
+	p, ref, err := v.parent(ptr)
+	if err != nil {
+		return err
+	}
+	if p.Type != AnyArr {
+		return errors.Errorf("insert into %s", p.Type)
+	}
+	i := len(p.Child)
+	if ref != "-" {
+		idx, ok := pointerIndex(ref)
+		if !ok || idx > len(p.Child) {
+			return errors.Wrapf(ErrPointerNotFound, "index %q", ref)
+		}
+		i = idx
+	}
+	val.Key, val.KeyValid = "", false
+	p.Child = append(p.Child, Any{})
+	copy(p.Child[i+1:], p.Child[i:])
+	p.Child[i] = val
+	return nil
+}
+
+// Delete removes value addressed by json pointer from its array or object.
+func (v *Any) Delete(ptr string) error {
+	p, ref, err := v.parent(ptr)
+	if err != nil {
+		return err
+	}
+	i, err := p.ref(ref)
+	if err != nil {
+		return err
+	}
+	copy(p.Child[i:], p.Child[i+1:])
+	// Vacated slot would share children with its former neighbour.
+	p.Child[len(p.Child)-1] = Any{}
+	p.Child = p.Child[:len(p.Child)-1]
+	return nil
+}
+
+// Rename changes key of object field addressed by json pointer, keeping
+// field position.
+//
+// Returns error if object already has field with such key.
+func (v *Any) Rename(ptr, key string) error {
+	p, ref, err := v.parent(ptr)
+	if err != nil {
+		return err
+	}
+	if p.Type != AnyObj {
+		return errors.Errorf("rename in %s", p.Type)
+	}
+	i, ok := p.field(ref)
+	if !ok {
+		return errors.Wrapf(ErrPointerNotFound, "key %q", ref)
+	}
+	if j, ok := p.field(key); ok && j != i {
+		return errors.Errorf("duplicate key %q", key)
+	}
+	p.Child[i].Key = key
+	return nil
+}
+
+// parent returns array or object containing value addressed by json pointer
+// and last reference token.
+func (v *Any) parent(ptr string) (*Any, string, error) {
+	refs, err := parsePointer(ptr)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(refs) == 0 {
+		return nil, "", errors.New("pointer to root has no parent")
+	}
+	for _, ref := range refs[:len(refs)-1] {
+		i, err := v.ref(ref)
+		if err != nil {
+			return nil, "", err
+		}
+		v = &v.Child[i]
+	}
+	return v, refs[len(refs)-1], nil
+}
+
+// ref returns index of child addressed by unescaped reference token.
+func (v *Any) ref(ref string) (int, error) {
+	switch v.Type {
+	case AnyObj:
+		if i, ok := v.field(ref); ok {
+			return i, nil
+		}
+		return 0, errors.Wrapf(ErrPointerNotFound, "key %q", ref)
+	case AnyArr:
+		i, ok := pointerIndex(ref)
+		if !ok || i >= len(v.Child) {
+			return 0, errors.Wrapf(ErrPointerNotFound, "index %q", ref)
+		}
+		return i, nil
+	default:
+		return 0, errors.Wrapf(ErrPointerNotFound, "reference %q in %s", ref, v.Type)
+	}
+}
+
+// field returns index of first child with key.
+func (v *Any) field(key string) (int, bool) {
+	for i, c := range v.Child {
+		if c.KeyValid && c.Key == key {
+			return i, true
+		}
+	}
+	return 0, false
+}
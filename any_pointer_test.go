@@ -0,0 +1,118 @@
+package jx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testAnyDoc(t *testing.T, input string) Any {
+	t.Helper()
+	v, err := DecodeStr(input).Any()
+	require.NoError(t, err)
+	return v
+}
+
+func encodeAny(v Any) string {
+	e := GetEncoder()
+	e.Any(v)
+	return e.String()
+}
+
+func TestAny_Get(t *testing.T) {
+	const input = `{"foo":["bar","baz"],"":0,"a/b":1,"m~n":{"x":[{"id":1}]},"foo":2}`
+	v := testAnyDoc(t, input)
+	for _, tt := range []struct {
+		Pointer string
+		Output  string
+	}{
+		{"", input},
+		{"/foo", `["bar","baz"]`},
+		{"/foo/1", `"baz"`},
+		{"/", `0`},
+		{"/a~1b", `1`},
+		{"/m~0n/x/0/id", `1`},
+	} {
+		got, err := v.Get(tt.Pointer)
+		require.NoError(t, err, tt.Pointer)
+		// Drop key of object field.
+		elem := *got
+		elem.KeyValid = false
+		require.Equal(t, tt.Output, encodeAny(elem), tt.Pointer)
+	}
+	for _, ptr := range []string{
+		"/bar",
+		"/foo/2",
+		"/foo/-",
+		"/foo/01",
+		"/a~1b/c",
+	} {
+		_, err := v.Get(ptr)
+		require.ErrorIs(t, err, ErrPointerNotFound, ptr)
+	}
+	_, err := v.Get("foo")
+	require.Error(t, err)
+	_, err = v.Get("/~2")
+	require.Error(t, err)
+}
+
+func TestAny_Edit(t *testing.T) {
+	const input = `{"a":1,"b":[1,2,3],"c":{"d":null}}`
+	for i, tt := range []struct {
+		Edit   func(v *Any) error
+		Output string
+	}{
+		{func(v *Any) error { return v.Set("/a", testAnyDoc(t, `"x"`)) }, `{"a":"x","b":[1,2,3],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Set("/e", testAnyDoc(t, `[]`)) }, `{"a":1,"b":[1,2,3],"c":{"d":null},"e":[]}`},
+		{func(v *Any) error { return v.Set("/b/0", testAnyDoc(t, `{"k":true}`)) }, `{"a":1,"b":[{"k":true},2,3],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Set("/b/-", testAnyDoc(t, `4`)) }, `{"a":1,"b":[1,2,3,4],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Set("/c/d", testAnyDoc(t, `false`)) }, `{"a":1,"b":[1,2,3],"c":{"d":false}}`},
+		{func(v *Any) error { return v.Set("", testAnyDoc(t, `[0]`)) }, `[0]`},
+		{func(v *Any) error { return v.Insert("/b/0", testAnyDoc(t, `0`)) }, `{"a":1,"b":[0,1,2,3],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Insert("/b/1", testAnyDoc(t, `"x"`)) }, `{"a":1,"b":[1,"x",2,3],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Insert("/b/3", testAnyDoc(t, `4`)) }, `{"a":1,"b":[1,2,3,4],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Insert("/b/-", testAnyDoc(t, `4`)) }, `{"a":1,"b":[1,2,3,4],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Delete("/a") }, `{"b":[1,2,3],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Delete("/b/1") }, `{"a":1,"b":[1,3],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Delete("/c/d") }, `{"a":1,"b":[1,2,3],"c":{}}`},
+		{func(v *Any) error { return v.Rename("/b", "z") }, `{"a":1,"z":[1,2,3],"c":{"d":null}}`},
+		{func(v *Any) error { return v.Rename("/a", "a") }, `{"a":1,"b":[1,2,3],"c":{"d":null}}`},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), func(t *testing.T) {
+			v := testAnyDoc(t, input)
+			require.NoError(t, tt.Edit(&v))
+			require.Equal(t, tt.Output, encodeAny(v))
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		for i, edit := range []func(v *Any) error{
+			func(v *Any) error { return v.Set("/b/3", Any{Type: AnyNull}) },
+			func(v *Any) error { return v.Set("/a/b", Any{Type: AnyNull}) },
+			func(v *Any) error { return v.Set("/x/y", Any{Type: AnyNull}) },
+			func(v *Any) error { return v.Insert("/b/4", Any{Type: AnyNull}) },
+			func(v *Any) error { return v.Insert("/c/d", Any{Type: AnyNull}) },
+			func(v *Any) error { return v.Insert("", Any{Type: AnyNull}) },
+			func(v *Any) error { return v.Delete("/b/3") },
+			func(v *Any) error { return v.Delete("/x") },
+			func(v *Any) error { return v.Delete("") },
+			func(v *Any) error { return v.Rename("/x", "y") },
+			func(v *Any) error { return v.Rename("/a", "b") },
+			func(v *Any) error { return v.Rename("/b/0", "x") },
+		} {
+			v := testAnyDoc(t, input)
+			require.Error(t, edit(&v), fmt.Sprintf("Test%d", i+1))
+			require.Equal(t, input, encodeAny(v))
+		}
+	})
+	t.Run("DeleteRead", func(t *testing.T) {
+		v := testAnyDoc(t, `[[1,2],[3,4]]`)
+		require.NoError(t, v.Delete("/0"))
+		require.Equal(t, `[[3,4]]`, encodeAny(v))
+		require.Equal(t, Any{}, v.Child[:2][1])
+
+		require.NoError(t, v.Read(DecodeStr(`[[5,6],[7,8]]`)))
+		require.Equal(t, `[[5,6],[7,8]]`, encodeAny(v))
+	})
+}
@@ -0,0 +1,279 @@
+package jx
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-faster/errors"
+)
+
+// Canonical reads single json value from d and writes its RFC 8785 (JCS)
+// canonical form: object keys sorted by UTF-16 code units, numbers
+// serialized like ECMAScript Number.prototype.toString and minimal string
+// escaping, without whitespace.
+//
+// Nothing is written on error. Value is rejected with *DuplicateKeyError
+// if any object has duplicate keys, with ErrInvalidUTF8 if strings have
+// invalid UTF-8 or unpaired surrogate escapes and with error if numbers
+// overflow float64.
+func (w *Writer) Canonical(d *Decoder) error {
+	c := getCanonicalizer()
+	defer putCanonicalizer(c)
+
+	if err := c.value(d); err != nil {
+		return err
+	}
+	if w.Raw(c.buf) {
+		return w.stream.writeErr
+	}
+	return nil
+}
+
+// Canonical reads single json value from d and writes its canonical form.
+//
+// See Writer.Canonical for details.
+func (e *Encoder) Canonical(d *Decoder) error {
+	c := getCanonicalizer()
+	defer putCanonicalizer(c)
+
+	if err := c.value(d); err != nil {
+		return err
+	}
+	return e.writeErr(e.Raw(c.buf))
+}
+
+// Canonical reports whether r is single json value in RFC 8785 (JCS)
+// canonical form.
+//
+// See Writer.Canonical.
+func (r Raw) Canonical() bool {
+	c := getCanonicalizer()
+	defer putCanonicalizer(c)
+
+	d := Decoder{buf: r, tail: len(r)}
+	if err := c.value(&d); err != nil {
+		return false
+	}
+	return d.end() == nil && bytes.Equal(c.buf, r)
+}
+
+// canonicalMember is object member written by canonicalizer.
+type canonicalMember struct {
+	keyStart, keyEnd int // unescaped key in canonicalizer.keys
+	start, end       int // serialized member in canonicalizer.buf
+}
+
+// canonicalizer writes canonical json to buffer, sorting object members
+// after they are written.
+type canonicalizer struct {
+	buf     []byte
+	tmp     []byte
+	keys    []byte
+	members []canonicalMember // members of objects being written
+}
+
+var canonicalizerPool = sync.Pool{
+	New: func() any { return &canonicalizer{} },
+}
+
+func getCanonicalizer() *canonicalizer {
+	return canonicalizerPool.Get().(*canonicalizer)
+}
+
+func putCanonicalizer(c *canonicalizer) {
+	c.buf = c.buf[:0]
+	c.tmp = c.tmp[:0]
+	c.keys = c.keys[:0]
+	c.members = c.members[:0]
+	canonicalizerPool.Put(c)
+}
+
+func (c *canonicalizer) value(d *Decoder) error {
+	// Reject duplicate keys and invalid strings during canonicalization.
+	reject, mode := d.opts.rejectDupKeys, d.opts.utf8
+	d.opts.rejectDupKeys, d.opts.utf8 = true, UTF8Strict
+	defer func() { d.opts.rejectDupKeys, d.opts.utf8 = reject, mode }()
+
+	c.buf = c.buf[:0]
+	c.keys = c.keys[:0]
+	c.members = c.members[:0]
+	return c.next(d)
+}
+
+func (c *canonicalizer) next(d *Decoder) error {
+	switch tt := d.Next(); tt {
+	case Object:
+		return c.obj(d)
+	case Array:
+		first := true
+		c.buf = append(c.buf, '[')
+		if err := d.Arr(func(d *Decoder) error {
+			if !first {
+				c.buf = append(c.buf, ',')
+			}
+			first = false
+			return c.next(d)
+		}); err != nil {
+			return err
+		}
+		c.buf = append(c.buf, ']')
+		return nil
+	case String:
+		s, err := d.StrBytes()
+		if err != nil {
+			return err
+		}
+		return c.str(s)
+	case Number:
+		v, err := d.Float64()
+		if err != nil {
+			return err
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return errors.Errorf("invalid number %v", v)
+		}
+		c.buf = floatAppendES(c.buf, v)
+		return nil
+	case Bool:
+		v, err := d.Bool()
+		if err != nil {
+			return err
+		}
+		if v {
+			c.buf = append(c.buf, "true"...)
+		} else {
+			c.buf = append(c.buf, "false"...)
+		}
+		return nil
+	case Null:
+		if err := d.Null(); err != nil {
+			return err
+		}
+		c.buf = append(c.buf, "null"...)
+		return nil
+	default:
+		return d.Skip()
+	}
+}
+
+func (c *canonicalizer) obj(d *Decoder) error {
+	mark := len(c.members)
+	start := len(c.buf)
+	if err := d.ObjBytes(func(d *Decoder, key []byte) error {
+		m := canonicalMember{
+			keyStart: len(c.keys),
+			start:    len(c.buf),
+		}
+		c.keys = append(c.keys, key...)
+		m.keyEnd = len(c.keys)
+		if err := c.str(key); err != nil {
+			return errors.Wrapf(err, "key %q", key)
+		}
+		c.buf = append(c.buf, ':')
+		if err := c.next(d); err != nil {
+			return errors.Wrapf(err, "field %q", key)
+		}
+		m.end = len(c.buf)
+		c.members = append(c.members, m)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Members are written without separators, reorder them.
+	members := c.members[mark:]
+	sort.Sort(canonicalMembers{c: c, members: members})
+	c.tmp = append(c.tmp[:0], c.buf[start:]...)
+	c.buf = append(c.buf[:start], '{')
+	for i, m := range members {
+		if i > 0 {
+			c.buf = append(c.buf, ',')
+		}
+		c.buf = append(c.buf, c.tmp[m.start-start:m.end-start]...)
+	}
+	c.buf = append(c.buf, '}')
+	c.members = c.members[:mark]
+	return nil
+}
+
+// str writes string with minimal escaping.
+func (c *canonicalizer) str(s []byte) error {
+	if !utf8.Valid(s) {
+		return ErrInvalidUTF8
+	}
+	c.buf = append(c.buf, '"')
+	for i := 0; i < len(s); {
+		n := scanStr(s[i:])
+		c.buf = append(c.buf, s[i:i+n]...)
+		i += n
+		if i == len(s) {
+			break
+		}
+		switch b := s[i]; b {
+		case '\\', '"':
+			c.buf = append(c.buf, '\\', b)
+		case '\b':
+			c.buf = append(c.buf, '\\', 'b')
+		case '\f':
+			c.buf = append(c.buf, '\\', 'f')
+		case '\n':
+			c.buf = append(c.buf, '\\', 'n')
+		case '\r':
+			c.buf = append(c.buf, '\\', 'r')
+		case '\t':
+			c.buf = append(c.buf, '\\', 't')
+		default:
+			c.buf = append(c.buf, '\\', 'u', '0', '0', hexChars[b>>4], hexChars[b&0xF])
+		}
+		i++
+	}
+	c.buf = append(c.buf, '"')
+	return nil
+}
+
+// canonicalMembers sorts object members by keys.
+type canonicalMembers struct {
+	c       *canonicalizer
+	members []canonicalMember
+}
+
+func (m canonicalMembers) Len() int { return len(m.members) }
+
+func (m canonicalMembers) Swap(i, j int) {
+	m.members[i], m.members[j] = m.members[j], m.members[i]
+}
+
+func (m canonicalMembers) Less(i, j int) bool {
+	a, b := m.members[i], m.members[j]
+	return lessUTF16(m.c.keys[a.keyStart:a.keyEnd], m.c.keys[b.keyStart:b.keyEnd])
+}
+
+// lessUTF16 reports whether valid UTF-8 string a sorts before b when
+// compared by UTF-16 code units.
+func lessUTF16(a, b []byte) bool {
+	for len(a) > 0 && len(b) > 0 {
+		ra, na := utf8.DecodeRune(a)
+		rb, nb := utf8.DecodeRune(b)
+		if ra != rb {
+			ua, ub := utf16First(ra), utf16First(rb)
+			if ua != ub {
+				return ua < ub
+			}
+			// Same high surrogate, low surrogates are ordered like runes.
+			return ra < rb
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return len(a) < len(b)
+}
+
+// utf16First returns first UTF-16 code unit of rune.
+func utf16First(r rune) rune {
+	if r < 0x10000 {
+		return r
+	}
+	return 0xd800 + (r-0x10000)>>10
+}
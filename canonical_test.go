@@ -0,0 +1,247 @@
+package jx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Canonical(t *testing.T) {
+	for i, tt := range []struct {
+		Input    string
+		Expected string
+	}{
+		// RFC 8785, Section 3.2.2.
+		{
+			Input: `{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`,
+			Expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		// RFC 8785, Section 3.2.3.
+		{
+			Input: `{
+  "\u20ac": "Euro Sign",
+  "\r": "Carriage Return",
+  "\ufb33": "Hebrew Letter Dalet With Dagesh",
+  "1": "One",
+  "\ud83d\ude00": "Emoji: Grinning Face",
+  "\u0080": "Control",
+  "\u00f6": "Latin Small Letter O With Diaeresis"
+}`,
+			Expected: "{" + strings.Join([]string{
+				`"\r":"Carriage Return"`,
+				`"1":"One"`,
+				"\"\u0080\":\"Control\"",
+				"\"\u00f6\":\"Latin Small Letter O With Diaeresis\"",
+				"\"\u20ac\":\"Euro Sign\"",
+				"\"\U0001F600\":\"Emoji: Grinning Face\"",
+				"\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"",
+			}, ",") + "}",
+		},
+		{
+			Input:    ` { "b" : [ { "d" : 1 , "c" : { } } , [ ] ] , "a" : -0.0 } `,
+			Expected: `{"a":0,"b":[{"c":{},"d":1},[]]}`,
+		},
+		{
+			Input:    `"\b\f\u0001\u001f\u007f<>& "`,
+			Expected: "\"\\b\\f\\u0001\\u001f\u007f<>& \"",
+		},
+		{
+			Input:    `[1.0, 100, 1e21, 1e-7, 123456789012345678901234567890, -1.5e-7]`,
+			Expected: `[1,100,1e+21,1e-7,1.2345678901234568e+29,-1.5e-7]`,
+		},
+		{
+			Input:    `{"":"","a":{"":1}}`,
+			Expected: `{"":"","a":{"":1}}`,
+		},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+			var w Writer
+			require.NoError(t, w.Canonical(d))
+			require.Equal(t, tt.Expected, w.String())
+			require.True(t, Raw(w.Buf).Canonical())
+		}))
+	}
+}
+
+func TestWriter_Canonical_Number(t *testing.T) {
+	// RFC 8785, Appendix B.
+	for i, tt := range []struct {
+		Bits     uint64
+		Expected string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), func(t *testing.T) {
+			// Write with full precision to test parsing too.
+			input := fmt.Sprintf("%.30g", math.Float64frombits(tt.Bits))
+			var w Writer
+			require.NoError(t, w.Canonical(DecodeStr(input)), input)
+			require.Equal(t, tt.Expected, w.String(), input)
+		})
+	}
+}
+
+func TestWriter_Canonical_Error(t *testing.T) {
+	for i, input := range []string{
+		``,
+		`{`,
+		`[1,]`,
+		`{"a":1,"a":2}`,
+		`[{"b":{"a":1,"a":2}}]`,
+		`1e400`,
+		"\"\xff\"",
+		"{\"\xff\":1}",
+		`{"a":"\ud800"}`,
+		`["\udc00\ud800"]`,
+		`{"\ud83d":1}`,
+	} {
+		input := input
+		t.Run(fmt.Sprintf("Test%d", i+1), testBufferReader(input, func(t *testing.T, d *Decoder) {
+			var w Writer
+			require.Error(t, w.Canonical(d))
+			require.Empty(t, w.Buf)
+			require.False(t, Raw(input).Canonical())
+		}))
+	}
+	t.Run("DuplicateKey", func(t *testing.T) {
+		var w Writer
+		var dupErr *DuplicateKeyError
+		require.ErrorAs(t, w.Canonical(DecodeStr(`{"a":{"b":1,"b":2}}`)), &dupErr)
+		require.Equal(t, "b", dupErr.Key)
+	})
+	t.Run("InvalidUTF8", func(t *testing.T) {
+		var w Writer
+		require.ErrorIs(t, w.Canonical(DecodeStr("[\"\xff\"]")), ErrInvalidUTF8)
+		require.ErrorIs(t, w.Canonical(DecodeStr(`{"a":"\ud800"}`)), ErrInvalidUTF8)
+		require.Empty(t, w.Buf)
+
+		// Mode of decoder is restored.
+		d := DecodeStr(`"\ud800"`)
+		d.SetUTF8Mode(UTF8Replace)
+		require.ErrorIs(t, w.Canonical(d), ErrInvalidUTF8)
+		d.ResetBytes([]byte(`"\ud800"`))
+		s, err := d.Str()
+		require.NoError(t, err)
+		require.Equal(t, "\uFFFD", s)
+	})
+}
+
+func TestEncoder_Canonical(t *testing.T) {
+	testEncoderModes(t, func(e *Encoder) {
+		e.ArrStart()
+		require.NoError(t, e.Canonical(DecodeStr(`{"b":1,"a":[2]}`)))
+		require.NoError(t, e.Canonical(DecodeStr(`3.0`)))
+		e.ArrEnd()
+	}, `[{"a":[2],"b":1},3]`)
+}
+
+func TestRaw_Canonical(t *testing.T) {
+	for i, tt := range []struct {
+		Input     string
+		Canonical bool
+	}{
+		{`{"a":1,"b":[true,null,"c"]}`, true},
+		{`1e+21`, true},
+		{`"\u000f"`, true},
+		{`{"b":1,"a":2}`, false},
+		{`{"a": 1}`, false},
+		{` 1`, false},
+		{`1 `, false},
+		{`1.0`, false},
+		{`1e21`, false},
+		{`-0`, false},
+		{`"\u000F"`, false},
+		{`"\/"`, false},
+		{`"\u0041"`, false},
+		{`"\u0008"`, false},
+		{`1 2`, false},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), func(t *testing.T) {
+			require.Equal(t, tt.Canonical, Raw(tt.Input).Canonical())
+		})
+	}
+}
+
+func TestLessUTF16(t *testing.T) {
+	keys := []string{"", "\r", "1", "a", "ab", "\u0080", "\u00f6", "\u20ac", "\U0001F600", "\U0001F601", "\U00010000\u0000", "\ufb33", "\uffff"}
+	// Order by UTF-16 code units.
+	units := func(s string) []uint16 {
+		var r []uint16
+		for _, c := range s {
+			if c >= 0x10000 {
+				c -= 0x10000
+				r = append(r, uint16(0xd800+c>>10), uint16(0xdc00+c&0x3ff))
+			} else {
+				r = append(r, uint16(c))
+			}
+		}
+		return r
+	}
+	less := func(a, b []uint16) bool {
+		ab := make([]byte, 2*len(a))
+		bb := make([]byte, 2*len(b))
+		for i, u := range a {
+			binary.BigEndian.PutUint16(ab[2*i:], u)
+		}
+		for i, u := range b {
+			binary.BigEndian.PutUint16(bb[2*i:], u)
+		}
+		return string(ab) < string(bb)
+	}
+	for _, a := range keys {
+		for _, b := range keys {
+			require.Equal(t, less(units(a), units(b)), lessUTF16([]byte(a), []byte(b)), "%q < %q", a, b)
+		}
+	}
+}
+
+func BenchmarkWriter_Canonical(b *testing.B) {
+	data := []byte(`{"numbers":[333333333.33333329,1E30,4.50,2e-3],"string":"€$\u000F\u000aA'B","literals":[null,true,false],"nested":{"z":1,"y":[{"b":2,"a":1}]}}`)
+	var (
+		w Writer
+		d Decoder
+	)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		w.Reset()
+		d.ResetBytes(data)
+		if err := w.Canonical(&d); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
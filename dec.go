@@ -86,7 +86,30 @@ type Decoder struct {
 	tail int // offset in buf to end of current json stream
 
 	streamOffset int // for reader, offset in stream to start of current buf contents
+	line         int // for reader, count of newlines in stream before current buf contents
+	lineStart    int // for reader, offset in stream to start of line containing buf start
 	depth        int
+	mark         lineMark // for reader, see StreamIter.Recover
+
+	scratch []byte                // reusable buffer for internal decoding
+	keys    []map[string]struct{} // object keys by depth, see SetRejectDuplicateKeys
+
+	tokens      []tokenFrame // arrays and objects opened by Token
+	tokenOffset int          // offset after last Token call
+	// opts are decoding options, preserved across Reset and ResetBytes.
+	opts decoderOptions
+}
+
+// decoderOptions holds Decoder options, zero value is default.
+type decoderOptions struct {
+	maxDepth  int      // zero means defaultMaxDepth
+	trackPath bool     // see SetTrackPath
+	utf8      UTF8Mode // see SetUTF8Mode
+
+	rejectDupKeys bool   // see SetRejectDuplicateKeys
+	syntax        Syntax // see SetSyntax
+
+	nonFiniteStrings bool // see SetNonFiniteStrings
 }
 
 const defaultBuf = 512
@@ -120,11 +143,18 @@ func (d *Decoder) offset() int {
 }
 
 // Reset resets reader and underlying state, next reads will use provided io.Reader.
+//
+// Options like maximum depth are preserved.
 func (d *Decoder) Reset(reader io.Reader) {
 	d.reader = reader
 	d.head = 0
 	d.tail = 0
 	d.depth = 0
+	d.tokens = d.tokens[:0]
+	d.streamOffset = 0
+	d.line = 0
+	d.lineStart = 0
+	d.mark.reset()
 
 	// Reads from reader need buffer.
 	if cap(d.buf) == 0 {
@@ -138,11 +168,18 @@ func (d *Decoder) Reset(reader io.Reader) {
 }
 
 // ResetBytes resets underlying state, next reads will use provided buffer.
+//
+// Options like maximum depth are preserved.
 func (d *Decoder) ResetBytes(input []byte) {
 	d.reader = nil
 	d.head = 0
 	d.tail = len(input)
 	d.depth = 0
+	d.tokens = d.tokens[:0]
+	d.streamOffset = 0
+	d.line = 0
+	d.lineStart = 0
+	d.mark.reset()
 
 	d.buf = input
 }
@@ -27,14 +27,32 @@ func (d *Decoder) Elem() (ok bool, err error) {
 	case ']':
 		return false, nil
 	case ',':
-		return true, nil
+		end, err := d.trailingComma(']')
+		if err != nil {
+			return false, err
+		}
+		return !end, nil
 	default:
-		return false, errors.Wrap(badToken(c, d.offset()), `"[", "," or "]" expected`)
+		return false, errors.Wrap(d.badToken(c, d.offset()), `"[", "," or "]" expected`)
 	}
 }
 
 // Arr decodes array and invokes callback on each array element.
 func (d *Decoder) Arr(f func(d *Decoder) error) error {
+	if d.opts.trackPath && f != nil {
+		index := 0
+		return rootPathErr(d.arr(func(d *Decoder) error {
+			if err := f(d); err != nil {
+				return pathErr(err, pathElem{index: index})
+			}
+			index++
+			return nil
+		}))
+	}
+	return d.arr(f)
+}
+
+func (d *Decoder) arr(f func(d *Decoder) error) error {
 	if err := d.consume('['); err != nil {
 		return errors.Wrap(err, `"[" expected`)
 	}
@@ -61,6 +79,13 @@ func (d *Decoder) Arr(f func(d *Decoder) error) error {
 		return errors.Wrap(err, `"," or "]" expected`)
 	}
 	for c == ',' {
+		end, err := d.trailingComma(']')
+		if err != nil {
+			return err
+		}
+		if end {
+			return d.decDepth()
+		}
 		// Skip whitespace before reading element.
 		if _, err := d.next(); err != nil {
 			return err
@@ -74,7 +99,7 @@ func (d *Decoder) Arr(f func(d *Decoder) error) error {
 		}
 	}
 	if c != ']' {
-		err := badToken(c, d.offset()-1)
+		err := d.badToken(c, d.offset()-1)
 		return errors.Wrap(err, `"]" expected`)
 	}
 	return d.decDepth()
@@ -46,10 +46,20 @@ func (i *ArrIter) Next() bool {
 	}
 	if i.comma {
 		if c != ',' {
-			err := badToken(c, dec.offset()-1)
+			err := dec.badToken(c, dec.offset()-1)
 			i.err = errors.Wrap(err, `"," expected`)
 			return false
 		}
+		end, err := dec.trailingComma(']')
+		if err != nil {
+			i.err = err
+			return false
+		}
+		if end {
+			i.closed = true
+			i.err = dec.decDepth()
+			return false
+		}
 	} else {
 		dec.unread()
 	}
@@ -36,8 +36,8 @@ func TestDecoder_ArrIter(t *testing.T) {
 	t.Run("Depth", func(t *testing.T) {
 		d := DecodeStr(`[`)
 		// Emulate depth
-		d.depth = maxDepth
-		require.ErrorIs(t, testIter(d), errMaxDepth)
+		d.depth = defaultMaxDepth
+		require.ErrorIs(t, testIter(d), ErrMaxDepth)
 	})
 	t.Run("Empty", func(t *testing.T) {
 		d := DecodeStr(``)
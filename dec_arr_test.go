@@ -46,11 +46,11 @@ func TestDecoder_Arr(t *testing.T) {
 	})
 	t.Run("Depth", func(t *testing.T) {
 		var data []byte
-		for i := 0; i <= maxDepth; i++ {
+		for i := 0; i <= defaultMaxDepth; i++ {
 			data = append(data, '[')
 		}
 		d := DecodeBytes(data)
-		require.ErrorIs(t, d.Arr(nil), errMaxDepth)
+		require.ErrorIs(t, d.Arr(nil), ErrMaxDepth)
 	})
 }
 
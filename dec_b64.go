@@ -40,7 +40,7 @@ func (d *Decoder) Base64Append(b []byte) ([]byte, error) {
 
 	n, err := base64.StdEncoding.Decode(b[start:], buf)
 	if err != nil {
-		return nil, errors.Wrap(err, "decode")
+		return nil, errors.Wrap(classify(ErrInvalidBase64, err), "decode")
 	}
 
 	return b[:start+n], nil
@@ -11,21 +11,36 @@ func (d *Decoder) Capture(f func(d *Decoder) error) error {
 		return nil
 	}
 
-	if d.reader != nil {
+	if orig := d.reader; orig != nil {
 		// TODO(tdakkota): May it be more efficient?
 		var (
 			buf          bytes.Buffer
 			streamOffset = d.streamOffset
+			line         = d.line
+			lineStart    = d.lineStart
+			mark         = d.mark
+			// Reads in f overwrite current buffer, save it to roll back.
+			saved = append([]byte(nil), d.buf[:d.tail]...)
 		)
-		reader := io.TeeReader(d.reader, &buf)
+		reader := io.TeeReader(orig, &buf)
 		defer func() {
-			d.reader = io.MultiReader(&buf, d.reader)
+			d.reader = io.MultiReader(&buf, orig)
 			d.streamOffset = streamOffset
+			d.line, d.lineStart = line, lineStart
+			d.mark = mark
+			copy(d.buf, saved)
 		}()
 		d.reader = reader
 	}
 	head, tail, depth := d.head, d.tail, d.depth
+	tokenOffset, tokens := d.tokenOffset, len(d.tokens)
+	var saved []tokenFrame
+	if tokens > 0 {
+		// Token state of open arrays and objects may be changed by f.
+		saved = append(saved, d.tokens...)
+	}
 	err := f(d)
 	d.head, d.tail, d.depth = head, tail, depth
+	d.tokenOffset, d.tokens = tokenOffset, append(d.tokens[:0], saved...)
 	return err
 }
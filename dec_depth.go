@@ -2,15 +2,38 @@ package jx
 
 import "github.com/go-faster/errors"
 
-// limit maximum depth of nesting, as allowed by https://tools.ietf.org/html/rfc7159#section-9
-const maxDepth = 10000
+// defaultMaxDepth limits maximum depth of nesting, as allowed by https://tools.ietf.org/html/rfc7159#section-9
+const defaultMaxDepth = 10000
 
-var errMaxDepth = errors.New("depth: maximum")
+// ErrMaxDepth is returned when maximum depth of nesting is exceeded.
+//
+// See Decoder.SetMaxDepth.
+var ErrMaxDepth = errors.New("depth: maximum")
+
+// SetMaxDepth sets maximum depth of nesting for objects and arrays.
+//
+// Non-positive value resets limit to default (10000).
+//
+// Limit is preserved across Reset and ResetBytes.
+func (d *Decoder) SetMaxDepth(n int) {
+	if n < 0 {
+		n = 0
+	}
+	d.opts.maxDepth = n
+}
+
+// MaxDepth returns maximum depth of nesting.
+func (d *Decoder) MaxDepth() int {
+	if d.opts.maxDepth == 0 {
+		return defaultMaxDepth
+	}
+	return d.opts.maxDepth
+}
 
 func (d *Decoder) incDepth() error {
 	d.depth++
-	if d.depth > maxDepth {
-		return errMaxDepth
+	if d.depth > d.MaxDepth() {
+		return ErrMaxDepth
 	}
 	return nil
 }
@@ -0,0 +1,104 @@
+package jx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_SetMaxDepth(t *testing.T) {
+	const limit = 3
+	var (
+		arrOk   = strings.Repeat("[", limit) + strings.Repeat("]", limit)
+		arrDeep = strings.Repeat("[", limit+1) + strings.Repeat("]", limit+1)
+		objOk   = strings.Repeat(`{"a":`, limit-1) + `{}` + strings.Repeat("}", limit-1)
+		objDeep = strings.Repeat(`{"a":`, limit) + `{}` + strings.Repeat("}", limit)
+	)
+	for _, tt := range []struct {
+		Name string
+		Fn   func(d *Decoder) error
+	}{
+		{"Skip", func(d *Decoder) error { return d.Skip() }},
+		{"Validate", func(d *Decoder) error { return d.Validate() }},
+		{"Raw", decoderOnlyError((*Decoder).Raw)},
+		{"Capture", func(d *Decoder) error {
+			return d.Capture(func(d *Decoder) error { return d.Skip() })
+		}},
+		{"Crawl", crawlValue},
+		{"Iter", func(d *Decoder) error {
+			switch d.Next() {
+			case Array:
+				iter, err := d.ArrIter()
+				if err != nil {
+					return err
+				}
+				for iter.Next() {
+					if err := crawlValue(d); err != nil {
+						return err
+					}
+				}
+				return iter.Err()
+			default:
+				iter, err := d.ObjIter()
+				if err != nil {
+					return err
+				}
+				for iter.Next() {
+					if err := crawlValue(d); err != nil {
+						return err
+					}
+				}
+				return iter.Err()
+			}
+		}},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			for _, input := range []string{arrOk, objOk} {
+				testBufferReader(input, func(t *testing.T, d *Decoder) {
+					d.SetMaxDepth(limit)
+					require.NoError(t, tt.Fn(d))
+				})(t)
+			}
+			for _, input := range []string{arrDeep, objDeep} {
+				testBufferReader(input, func(t *testing.T, d *Decoder) {
+					d.SetMaxDepth(limit)
+					require.ErrorIs(t, tt.Fn(d), ErrMaxDepth)
+				})(t)
+			}
+		})
+	}
+	t.Run("Reset", func(t *testing.T) {
+		d := GetDecoder()
+		defer PutDecoder(d)
+
+		d.SetMaxDepth(limit)
+		d.ResetBytes([]byte(arrDeep))
+		require.ErrorIs(t, d.Skip(), ErrMaxDepth)
+		d.Reset(strings.NewReader(arrDeep))
+		require.ErrorIs(t, d.Skip(), ErrMaxDepth)
+		require.Equal(t, limit, d.MaxDepth())
+	})
+	t.Run("Default", func(t *testing.T) {
+		d := DecodeStr(arrDeep)
+		require.Equal(t, defaultMaxDepth, d.MaxDepth())
+		d.SetMaxDepth(limit)
+		d.SetMaxDepth(-1)
+		require.Equal(t, defaultMaxDepth, d.MaxDepth())
+		require.NoError(t, d.Skip())
+
+		d.SetMaxDepth(limit)
+		PutDecoder(d)
+		require.Equal(t, defaultMaxDepth, d.MaxDepth())
+	})
+	t.Run("Deeper", func(t *testing.T) {
+		input := strings.Repeat("[", defaultMaxDepth+1) + strings.Repeat("]", defaultMaxDepth+1)
+		d := DecodeStr(input)
+		require.ErrorIs(t, d.Validate(), ErrMaxDepth)
+
+		d.ResetBytes([]byte(input))
+		d.SetMaxDepth(defaultMaxDepth + 1)
+		require.NoError(t, d.Validate())
+	})
+}
@@ -0,0 +1,352 @@
+package jx
+
+import (
+	"github.com/go-faster/errors"
+
+	"github.com/go-faster/jx/internal/byteseq"
+)
+
+// Doc is structural index of json document for random access to its values
+// without re-scanning.
+//
+// Document is scanned once, recording offsets of every value and children
+// of every array and object. Keys of large objects are hashed, so field
+// lookup does not depend on object size. Doc can be reused by calling Parse.
+type Doc struct {
+	data     []byte
+	nodes    []docNode // values in order of appearance
+	children []int     // node indexes of children, grouped by parent
+	keys     []byte    // unescaped object keys
+	index    []int     // key hash tables of large objects, node index + 1
+	stack    []int     // children of arrays and objects being indexed
+}
+
+// docIndexMin is minimum count of object fields to build key hash table,
+// smaller objects are scanned.
+const docIndexMin = 8
+
+// docNode is indexed json value.
+type docNode struct {
+	typ        Type
+	start, end int // value offsets
+	keyStart   int // object key offsets in Doc.keys
+	keyEnd     int
+	children   int // offset of children in Doc.children
+	count      int // count of children
+	index      int // offset of key hash table in Doc.index, see docIndexMin
+}
+
+// ParseDoc indexes single json value from data.
+//
+// Doc references data, so data should not be modified.
+func ParseDoc(data []byte) (*Doc, error) {
+	doc := &Doc{}
+	if err := doc.Parse(data); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Parse resets Doc and indexes single json value from data.
+//
+// Returns error matching ErrTrailingData if there is any data after value.
+func (doc *Doc) Parse(data []byte) error {
+	doc.data = data
+	doc.nodes = doc.nodes[:0]
+	doc.children = doc.children[:0]
+	doc.keys = doc.keys[:0]
+	doc.index = doc.index[:0]
+	doc.stack = doc.stack[:0]
+
+	d := Decoder{buf: data, tail: len(data)}
+	if _, err := doc.value(&d); err != nil {
+		doc.nodes = doc.nodes[:0]
+		return err
+	}
+	if err := d.end(); err != nil {
+		doc.nodes = doc.nodes[:0]
+		return err
+	}
+	return nil
+}
+
+// value indexes next value and returns its node index.
+func (doc *Doc) value(d *Decoder) (int, error) {
+	if err := d.skipSpace(); err != nil {
+		return 0, err
+	}
+	i := len(doc.nodes)
+	typ := d.Next()
+	doc.nodes = append(doc.nodes, docNode{
+		typ:   typ,
+		start: d.head,
+	})
+	mark := len(doc.stack)
+
+	var err error
+	switch typ {
+	case Object:
+		err = d.ObjBytes(func(d *Decoder, key []byte) error {
+			keyStart := len(doc.keys)
+			doc.keys = append(doc.keys, key...)
+			keyEnd := len(doc.keys)
+			j, err := doc.value(d)
+			if err != nil {
+				return err
+			}
+			n := &doc.nodes[j]
+			n.keyStart, n.keyEnd = keyStart, keyEnd
+			doc.stack = append(doc.stack, j)
+			return nil
+		})
+	case Array:
+		err = d.Arr(func(d *Decoder) error {
+			j, err := doc.value(d)
+			if err != nil {
+				return err
+			}
+			doc.stack = append(doc.stack, j)
+			return nil
+		})
+	default:
+		err = d.Skip()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	n := &doc.nodes[i]
+	n.end = d.head
+	n.children = len(doc.children)
+	n.count = len(doc.stack) - mark
+	doc.children = append(doc.children, doc.stack[mark:]...)
+	doc.stack = doc.stack[:mark]
+	if typ == Object && n.count >= docIndexMin {
+		doc.indexKeys(n)
+	}
+	return i, nil
+}
+
+// indexKeys builds key hash table of object node.
+func (doc *Doc) indexKeys(n *docNode) {
+	size := docTableSize(n.count)
+	n.index = len(doc.index)
+	for i := 0; i < size; i++ {
+		doc.index = append(doc.index, 0)
+	}
+	table := doc.index[n.index:]
+	mask := uint64(size - 1)
+Fields:
+	for _, j := range doc.children[n.children : n.children+n.count] {
+		key := doc.key(j)
+		for h := keyHash(key) & mask; ; h = (h + 1) & mask {
+			switch k := table[h]; {
+			case k == 0:
+				table[h] = j + 1
+				continue Fields
+			case string(doc.key(k-1)) == string(key):
+				// Duplicate key, first one is used.
+				continue Fields
+			}
+		}
+	}
+}
+
+// key returns unescaped key of i-th node.
+func (doc *Doc) key(i int) []byte {
+	n := &doc.nodes[i]
+	return doc.keys[n.keyStart:n.keyEnd]
+}
+
+// docTableSize returns size of key hash table for given count of fields,
+// which is power of two and is at least twice larger.
+func docTableSize(count int) int {
+	size := 1
+	for size < 2*count {
+		size <<= 1
+	}
+	return size
+}
+
+// keyHash is FNV-1a hash of key.
+func keyHash[S byteseq.Byteseq](key S) uint64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	h := uint64(offset)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= prime
+	}
+	return h
+}
+
+// Root returns root value of document.
+//
+// Returns zero DocNode if Doc is not parsed.
+func (doc *Doc) Root() DocNode {
+	if len(doc.nodes) == 0 {
+		return DocNode{}
+	}
+	return DocNode{doc: doc}
+}
+
+// DocNode is value of indexed document.
+//
+// Zero value means that there is no such value, so lookups can be chained:
+//
+//	doc.Root().Get("items").Index(3).Get("id")
+type DocNode struct {
+	doc *Doc
+	i   int
+}
+
+func (n DocNode) node() *docNode {
+	return &n.doc.nodes[n.i]
+}
+
+// Exists reports whether value exists.
+func (n DocNode) Exists() bool {
+	return n.doc != nil
+}
+
+// Type of value, Invalid if value does not exist.
+func (n DocNode) Type() Type {
+	if n.doc == nil {
+		return Invalid
+	}
+	return n.node().typ
+}
+
+// Raw returns json of value.
+func (n DocNode) Raw() Raw {
+	if n.doc == nil {
+		return nil
+	}
+	v := n.node()
+	return n.doc.data[v.start:v.end]
+}
+
+// Key returns unescaped key if value is object field.
+//
+// Do not modify returned value, it references Doc buffer.
+func (n DocNode) Key() []byte {
+	if n.doc == nil {
+		return nil
+	}
+	v := n.node()
+	return n.doc.keys[v.keyStart:v.keyEnd]
+}
+
+// Len returns count of array elements or object fields.
+func (n DocNode) Len() int {
+	if n.doc == nil {
+		return 0
+	}
+	return n.node().count
+}
+
+// Index returns i-th element of array or i-th field of object.
+func (n DocNode) Index(i int) DocNode {
+	if n.doc == nil {
+		return DocNode{}
+	}
+	v := n.node()
+	if i < 0 || i >= v.count {
+		return DocNode{}
+	}
+	return DocNode{doc: n.doc, i: n.doc.children[v.children+i]}
+}
+
+// Get returns value of object field. If object has duplicate keys, first
+// one is used.
+func (n DocNode) Get(key string) DocNode {
+	if n.doc == nil {
+		return DocNode{}
+	}
+	v := n.node()
+	if v.typ != Object {
+		return DocNode{}
+	}
+	doc := n.doc
+	if v.count >= docIndexMin {
+		table := doc.index[v.index : v.index+docTableSize(v.count)]
+		mask := uint64(len(table) - 1)
+		for h := keyHash(key) & mask; table[h] != 0; h = (h + 1) & mask {
+			if j := table[h] - 1; string(doc.key(j)) == key {
+				return DocNode{doc: doc, i: j}
+			}
+		}
+		return DocNode{}
+	}
+	for _, j := range doc.children[v.children : v.children+v.count] {
+		if string(doc.key(j)) == key {
+			return DocNode{doc: doc, i: j}
+		}
+	}
+	return DocNode{}
+}
+
+// Pointer returns value addressed by RFC 6901 json pointer.
+//
+// Returns ErrPointerNotFound if there is no such value.
+func (n DocNode) Pointer(ptr string) (DocNode, error) {
+	refs, err := parsePointer(ptr)
+	if err != nil {
+		return DocNode{}, err
+	}
+	for _, ref := range refs {
+		switch n.Type() {
+		case Object:
+			n = n.Get(ref)
+		case Array:
+			idx, ok := pointerIndex(ref)
+			if !ok {
+				return DocNode{}, errors.Wrapf(ErrPointerNotFound, "index %q", ref)
+			}
+			n = n.Index(idx)
+		default:
+			n = DocNode{}
+		}
+		if n.doc == nil {
+			return DocNode{}, errors.Wrapf(ErrPointerNotFound, "reference %q", ref)
+		}
+	}
+	return n, nil
+}
+
+// Decoder returns Decoder of value.
+func (n DocNode) Decoder() *Decoder {
+	return DecodeBytes(n.Raw())
+}
+
+// dec returns decoder of value.
+func (n DocNode) dec() Decoder {
+	raw := n.Raw()
+	return Decoder{buf: raw, tail: len(raw)}
+}
+
+// Str decodes string value.
+func (n DocNode) Str() (string, error) {
+	d := n.dec()
+	return d.Str()
+}
+
+// Int64 decodes int64 value.
+func (n DocNode) Int64() (int64, error) {
+	d := n.dec()
+	return d.Int64()
+}
+
+// Float64 decodes float64 value.
+func (n DocNode) Float64() (float64, error) {
+	d := n.dec()
+	return d.Float64()
+}
+
+// Bool decodes bool value.
+func (n DocNode) Bool() (bool, error) {
+	d := n.dec()
+	return d.Bool()
+}
@@ -0,0 +1,214 @@
+package jx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoc(t *testing.T) {
+	const input = ` {"a": [1, {"bc": "d"}, [] ], "e": {}, "f": null, "a": 2.5, "t": true} `
+	doc, err := ParseDoc([]byte(input))
+	require.NoError(t, err)
+
+	root := doc.Root()
+	require.Equal(t, Object, root.Type())
+	require.Equal(t, 5, root.Len())
+	require.Equal(t, input[1:len(input)-1], root.Raw().String())
+
+	a := root.Get("a")
+	require.Equal(t, Array, a.Type())
+	require.Equal(t, "a", string(a.Key()))
+	require.Equal(t, 3, a.Len())
+	require.Equal(t, `1`, a.Index(0).Raw().String())
+	require.Equal(t, `{"bc": "d"}`, a.Index(1).Raw().String())
+	require.Equal(t, `[]`, a.Index(2).Raw().String())
+	require.Equal(t, "bc", string(a.Index(1).Index(0).Key()))
+
+	s, err := a.Index(1).Get("bc").Str()
+	require.NoError(t, err)
+	require.Equal(t, "d", s)
+	i, err := a.Index(0).Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), i)
+	b, err := root.Get("t").Bool()
+	require.NoError(t, err)
+	require.True(t, b)
+	f, err := root.Index(3).Float64()
+	require.NoError(t, err)
+	require.Equal(t, 2.5, f)
+	require.Equal(t, Null, root.Get("f").Type())
+	require.NoError(t, root.Get("f").Decoder().Null())
+
+	for _, n := range []DocNode{
+		root.Get("x"),
+		root.Get("f").Get("x"),
+		a.Index(3),
+		a.Index(-1),
+		a.Get("0"),
+		root.Get("x").Index(0).Get("y"),
+	} {
+		require.False(t, n.Exists())
+		require.Equal(t, Invalid, n.Type())
+		require.Nil(t, n.Raw())
+		require.Zero(t, n.Len())
+	}
+
+	n, err := root.Pointer("/a/1/bc")
+	require.NoError(t, err)
+	require.Equal(t, `"d"`, n.Raw().String())
+	for _, ptr := range []string{"/x", "/a/3", "/a/-", "/f/0", "/a/01"} {
+		_, err := root.Pointer(ptr)
+		require.ErrorIs(t, err, ErrPointerNotFound, ptr)
+	}
+	_, err = root.Pointer("a")
+	require.Error(t, err)
+}
+
+func TestDoc_Parse(t *testing.T) {
+	var doc Doc
+	require.False(t, doc.Root().Exists())
+	for i, input := range []string{
+		``,
+		` `,
+		`[1,]`,
+		`{"a":}`,
+		`[1] 2`,
+		`"a`,
+		`nul`,
+	} {
+		require.Error(t, doc.Parse([]byte(input)), fmt.Sprintf("Test%d", i+1))
+		require.False(t, doc.Root().Exists())
+	}
+	require.ErrorIs(t, doc.Parse([]byte(`{} {}`)), ErrTrailingData)
+	require.NoError(t, doc.Parse([]byte(`"a"`)))
+	require.Equal(t, `"a"`, doc.Root().Raw().String())
+}
+
+func TestDoc_Get(t *testing.T) {
+	for _, count := range []int{0, 1, docIndexMin - 1, docIndexMin, 100} {
+		var e Encoder
+		e.Obj(func(e *Encoder) {
+			for i := 0; i < count; i++ {
+				e.Field(fmt.Sprintf("k%d", i), func(e *Encoder) { e.Int(i) })
+			}
+			// Duplicates, first one is used.
+			for i := 0; i < count; i += 3 {
+				e.Field(fmt.Sprintf("k%d", i), func(e *Encoder) { e.Int(-1) })
+			}
+			e.Field("", func(e *Encoder) { e.Int(count) })
+		})
+		doc, err := ParseDoc(e.Bytes())
+		require.NoError(t, err)
+
+		root := doc.Root()
+		for i := 0; i < count; i++ {
+			key := fmt.Sprintf("k%d", i)
+			v, err := root.Get(key).Int64()
+			require.NoError(t, err, key)
+			require.Equal(t, int64(i), v, key)
+		}
+		v, err := root.Get("").Int64()
+		require.NoError(t, err)
+		require.Equal(t, int64(count), v)
+		for _, key := range []string{"k", "x", fmt.Sprintf("k%d", count), "k1 "} {
+			require.False(t, root.Get(key).Exists(), key)
+		}
+	}
+}
+
+func TestDoc_Pointer(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "citm_catalog.json"))
+	require.NoError(t, err)
+	doc, err := ParseDoc(data)
+	require.NoError(t, err)
+	for _, ptr := range []string{
+		"",
+		"/areaNames",
+		"/areaNames/205705993",
+		"/events/138586341/name",
+		"/performances/0/prices/1",
+		"/performances/100/seatCategories/0/areas",
+		"/venueNames/PLEYEL_PLEYEL",
+	} {
+		n, err := doc.Root().Pointer(ptr)
+		require.NoError(t, err, ptr)
+
+		d := DecodeBytes(data)
+		require.NoError(t, d.Pointer(ptr))
+		raw, err := d.Raw()
+		require.NoError(t, err)
+		require.Equal(t, raw.String(), n.Raw().String(), ptr)
+	}
+}
+
+func BenchmarkDoc(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("testdata", "citm_catalog.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("Parse", func(b *testing.B) {
+		var doc Doc
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if err := doc.Parse(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Get", func(b *testing.B) {
+		doc, err := ParseDoc(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if !doc.Root().Get("performances").Index(100).Get("seatCategories").Exists() {
+				b.Fatal("not found")
+			}
+		}
+	})
+}
+
+func BenchmarkDoc_Get(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("testdata", "citm_catalog.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	doc, err := ParseDoc(data)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// Look up every event, object has hundreds of fields.
+	events := doc.Root().Get("events")
+	keys := make([]string, events.Len())
+	for i := range keys {
+		keys[i] = string(events.Index(i).Key())
+	}
+	b.Run("Doc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, key := range keys {
+				if !doc.Root().Get("events").Get(key).Exists() {
+					b.Fatal("not found")
+				}
+			}
+		}
+	})
+	b.Run("Skip", func(b *testing.B) {
+		var d Decoder
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, key := range keys {
+				d.ResetBytes(data)
+				if err := d.Pointer("/events/" + key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
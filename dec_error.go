@@ -1,17 +1,113 @@
 package jx
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
 
-// badTokenErr means that Token was unexpected while decoding.
-type badTokenErr struct {
-	Token  byte
-	Offset int
+	"github.com/go-faster/errors"
+)
+
+// Decoding errors, use errors.Is to check for them.
+//
+// Unexpected tokens are reported as *SyntaxError, use errors.As to
+// retrieve it. Depth limit errors are reported as ErrMaxDepth.
+var (
+	// ErrUnexpectedEOF means that input ended in the middle of value.
+	//
+	// Same as io.ErrUnexpectedEOF.
+	ErrUnexpectedEOF = io.ErrUnexpectedEOF
+	// ErrOverflow means that number does not fit into requested type.
+	//
+	// Same as strconv.ErrRange.
+	ErrOverflow = strconv.ErrRange
+	// ErrInvalidNumber means that number is malformed or can't be
+	// represented as requested type, like 1.5 as integer.
+	ErrInvalidNumber = errors.New("invalid number")
+	// ErrInvalidEscape means that string contains invalid escape sequence.
+	ErrInvalidEscape = errors.New("invalid escape")
+	// ErrInvalidBase64 means that string is not valid base64.
+	ErrInvalidBase64 = errors.New("invalid base64")
+	// ErrTrailingData means that Validate found data after first value.
+	ErrTrailingData = errors.New("unexpected trailing data")
+)
+
+// classErr is err that also matches class with errors.Is.
+//
+// Message of err is kept intact.
+type classErr struct {
+	class error
+	err   error
+}
+
+func (e *classErr) Error() string { return e.err.Error() }
+
+func (e *classErr) Unwrap() error { return e.err }
+
+func (e *classErr) Is(target error) bool { return target == e.class }
+
+func classify(class, err error) error {
+	return &classErr{class: class, err: err}
 }
 
-func (e *badTokenErr) Error() string {
+// SyntaxError means that Token was unexpected while decoding.
+//
+// Use Line and Column to report human-readable position.
+type SyntaxError struct {
+	Token  byte // offending byte
+	Offset int  // offset of Token in input, starting from 0
+	Line   int  // line of Token, starting from 1, zero if unknown
+	Column int  // column of Token in bytes, starting from 1, zero if unknown
+}
+
+func (e *SyntaxError) Error() string {
 	return fmt.Sprintf("unexpected byte %d %q at %d", e.Token, e.Token, e.Offset)
 }
 
-func badToken(c byte, offset int) error {
-	return &badTokenErr{Token: c, Offset: offset}
+func (d *Decoder) badToken(c byte, offset int) error {
+	line, column := d.position(offset)
+	return &SyntaxError{
+		Token:  c,
+		Offset: offset,
+		Line:   line,
+		Column: column,
+	}
+}
+
+// position returns line and column of given offset in input.
+//
+// Offset is expected to be within current buffer, otherwise position
+// is approximated by start of the buffer.
+func (d *Decoder) position(offset int) (line, column int) {
+	rel := offset - d.streamOffset
+	switch {
+	case rel < 0:
+		rel = 0
+	case rel > d.tail:
+		rel = d.tail
+	}
+	prefix := d.buf[:rel]
+
+	line = d.line + bytes.Count(prefix, []byte{'\n'}) + 1
+	if idx := bytes.LastIndexByte(prefix, '\n'); idx >= 0 {
+		column = rel - idx
+	} else {
+		column = offset - d.lineStart + 1
+	}
+	if column < 1 {
+		column = 1
+	}
+	return line, column
+}
+
+// trackLines returns line tracking state after consuming current buffer.
+func (d *Decoder) trackLines() (line, lineStart int) {
+	line, lineStart = d.line, d.lineStart
+	buf := d.buf[:d.tail]
+	if n := bytes.Count(buf, []byte{'\n'}); n > 0 {
+		line += n
+		lineStart = d.streamOffset + bytes.LastIndexByte(buf, '\n') + 1
+	}
+	return line, lineStart
 }
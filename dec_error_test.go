@@ -1,16 +1,118 @@
 package jx
 
 import (
+	"strings"
 	"testing"
+	"testing/iotest"
 
+	"github.com/go-faster/errors"
 	"github.com/stretchr/testify/require"
 )
 
-func Test_badTokenErr_Error(t *testing.T) {
-	e := &badTokenErr{
+func TestSyntaxError_Error(t *testing.T) {
+	e := &SyntaxError{
 		Token:  'c',
 		Offset: 10,
 	}
 	s := error(e).Error()
 	require.Equal(t, "unexpected byte 99 'c' at 10", s)
 }
+
+func TestSyntaxError_Position(t *testing.T) {
+	for _, tt := range []struct {
+		Input  string
+		Line   int
+		Column int
+	}{
+		{"x", 1, 1},
+		{"[1, 2, x]", 1, 8},
+		{"\n\n  [1,\n 2,\n\tx]", 5, 2},
+		{"{\n  \"a\": 1,\n  \"b\" 2\n}", 3, 7},
+		{"{\n  \"a\": [\n    tru\n  ]\n}", 3, 8},
+		{"[\n\"\\u12x4\"]", 2, 6},
+		{"\n\n\n" + strings.Repeat(" ", 600) + "[1,\n\n 2x]", 6, 3},
+	} {
+		tt := tt
+		t.Run(tt.Input, testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+			err := d.Validate()
+			require.Error(t, err)
+
+			var se *SyntaxError
+			require.True(t, errors.As(err, &se), "%+v", err)
+			require.Equal(t, tt.Input[se.Offset], se.Token)
+			require.Equal(t, tt.Line, se.Line, "line")
+			require.Equal(t, tt.Column, se.Column, "column")
+		}))
+	}
+	t.Run("Capture", func(t *testing.T) {
+		const input = "[\n  {\"a\": 1},\n  {\"b\": 2},\n  {\"c\" 3}\n]"
+		for _, d := range []*Decoder{
+			DecodeStr(input),
+			Decode(strings.NewReader(input), 4),
+			Decode(iotest.OneByteReader(strings.NewReader(input)), 4),
+		} {
+			err := d.Arr(func(d *Decoder) error {
+				if err := d.Capture(func(d *Decoder) error {
+					return d.Skip()
+				}); err != nil {
+					return err
+				}
+				return d.Skip()
+			})
+			var se *SyntaxError
+			require.True(t, errors.As(err, &se), "%+v", err)
+			require.Equal(t, byte('3'), se.Token)
+			require.Equal(t, 4, se.Line)
+			require.Equal(t, 8, se.Column)
+		}
+	})
+}
+
+func TestDecoderErrors(t *testing.T) {
+	deep := strings.Repeat("[", defaultMaxDepth+1)
+	for _, tt := range []struct {
+		Name   string
+		Input  string
+		Decode func(d *Decoder) error
+		Err    error
+	}{
+		{"EOF", `{"foo":`, (*Decoder).Validate, ErrUnexpectedEOF},
+		{"EOFStr", `"foo`, decoderOnlyError((*Decoder).Str), ErrUnexpectedEOF},
+		{"EOFFloat", `-`, decoderOnlyError((*Decoder).Float64), ErrUnexpectedEOF},
+		{"Depth", deep, (*Decoder).Validate, ErrMaxDepth},
+		{"Overflow", `256`, decoderOnlyError((*Decoder).UInt8), ErrOverflow},
+		{"OverflowInt64", `9223372036854775808`, decoderOnlyError((*Decoder).Int64), ErrOverflow},
+		{"OverflowFloat", `1e400`, decoderOnlyError((*Decoder).Float64), ErrOverflow},
+		{"EscapeStr", `"\x"`, decoderOnlyError((*Decoder).Str), ErrInvalidEscape},
+		{"EscapeHex", `"\u12x4"`, decoderOnlyError((*Decoder).Str), ErrInvalidEscape},
+		{"EscapeSkip", `["\x"]`, (*Decoder).Validate, ErrInvalidEscape},
+		{"EscapeSkipHex", `["\u12x4"]`, (*Decoder).Validate, ErrInvalidEscape},
+		{"Base64", `"#"`, decoderOnlyError((*Decoder).Base64), ErrInvalidBase64},
+		{"Trailing", `{} {}`, (*Decoder).Validate, ErrTrailingData},
+		{"TrailingBad", `{}}`, (*Decoder).Validate, ErrTrailingData},
+		{"BigInt", `1.5`, decoderOnlyError((*Decoder).BigInt), ErrInvalidNumber},
+		{"Fraction", `1.5`, func(d *Decoder) error {
+			n, err := d.Num()
+			if err != nil {
+				return err
+			}
+			_, err = n.Int64()
+			return err
+		}, ErrInvalidNumber},
+	} {
+		tt := tt
+		t.Run(tt.Name, testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+			require.ErrorIs(t, tt.Decode(d), tt.Err)
+		}))
+	}
+	t.Run("Syntax", func(t *testing.T) {
+		for _, input := range []string{
+			`{"foo" 1}`,
+			`["\x"]`,
+			`{}}`,
+		} {
+			var se *SyntaxError
+			require.True(t, errors.As(DecodeStr(input).Validate(), &se), input)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package jx
 
 import (
 	"bytes"
+	"math"
 	"strconv"
 
 	"github.com/go-faster/errors"
@@ -45,8 +46,58 @@ func init() {
 	floatDigits['-'] = minusInNumber
 }
 
+// SetNonFiniteStrings sets whether Float32, Float64 and Num should accept
+// strings "NaN", "Infinity" and "-Infinity", as written in NonFiniteString
+// mode of Writer.
+//
+// Num returns such value as string, which Num.Float64 does not decode.
+func (d *Decoder) SetNonFiniteStrings(accept bool) {
+	d.opts.nonFiniteStrings = accept
+}
+
+// nonFiniteStr reads NaN or infinity string, if enabled and next value is
+// string.
+func (d *Decoder) nonFiniteStr() (v float64, ok bool, _ error) {
+	if !d.opts.nonFiniteStrings || d.Next() != String {
+		return 0, false, nil
+	}
+	offset := d.offset()
+	var buf [16]byte
+	s, err := d.str(value{buf: buf[:0]})
+	if err != nil {
+		return 0, false, err
+	}
+	v, ok = parseNonFinite(s.buf)
+	if !ok {
+		err := errors.Errorf("unexpected string %q at %d", s.buf, offset)
+		return 0, false, classify(ErrInvalidNumber, err)
+	}
+	return v, true, nil
+}
+
+// parseNonFinite parses NaN or infinity name.
+func parseNonFinite(s []byte) (float64, bool) {
+	switch string(s) {
+	case "NaN":
+		return math.NaN(), true
+	case "Infinity":
+		return math.Inf(1), true
+	case "-Infinity":
+		return math.Inf(-1), true
+	default:
+		return 0, false
+	}
+}
+
 // Float32 reads float32 value.
 func (d *Decoder) Float32() (float32, error) {
+	if v, ok, err := d.nonFiniteStr(); ok || err != nil {
+		return float32(v), err
+	}
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.float5(32)
+		return float32(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -75,12 +126,12 @@ func (d *Decoder) positiveFloat32() (float32, error) {
 	ind := floatDigits[c]
 	switch ind {
 	case invalidCharForNumber, endOfNumber:
-		return 0, badToken(c, d.offset())
+		return 0, d.badToken(c, d.offset())
 	case dotInNumber, plusInNumber, expInNumber:
-		err := badToken(c, d.offset())
+		err := d.badToken(c, d.offset())
 		return 0, errors.Wrapf(err, "leading %q", c)
 	case minusInNumber: // minus handled by caller
-		err := badToken(c, d.offset())
+		err := d.badToken(c, d.offset())
 		return 0, errors.Wrap(err, "double minus")
 	case 0:
 		if i == d.tail {
@@ -88,7 +139,7 @@ func (d *Decoder) positiveFloat32() (float32, error) {
 		}
 		c = d.buf[i]
 		if floatDigits[c] >= 0 {
-			err := badToken(c, d.offset()+1)
+			err := d.badToken(c, d.offset()+1)
 			return 0, errors.Wrap(err, "leading zero")
 		}
 	}
@@ -100,7 +151,10 @@ NonDecimalLoop:
 		ind := floatDigits[c]
 		switch ind {
 		case invalidCharForNumber:
-			return 0, badToken(c, d.offset()+i)
+			if !d.commentStart(c) {
+				return 0, d.badToken(c, d.offset()+i)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			return float32(value), nil
@@ -123,6 +177,13 @@ NonDecimalLoop:
 			c = d.buf[i]
 			ind := floatDigits[c]
 			switch ind {
+			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
+				return d.float32Slow()
+			case invalidCharForNumber:
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset()+i)
+				}
+				fallthrough
 			case endOfNumber:
 				if decimalPlaces > 0 && decimalPlaces < len(pow10) {
 					d.head = i
@@ -130,10 +191,6 @@ NonDecimalLoop:
 				}
 				// too many decimal places
 				return d.float32Slow()
-			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
-				return d.float32Slow()
-			case invalidCharForNumber:
-				return 0, badToken(c, d.offset()+i)
 			}
 			decimalPlaces++
 			if value > uint64SafeToMultiple10 {
@@ -147,6 +204,13 @@ NonDecimalLoop:
 
 // Float64 read float64
 func (d *Decoder) Float64() (float64, error) {
+	if v, ok, err := d.nonFiniteStr(); ok || err != nil {
+		return v, err
+	}
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.float5(64)
+		return float64(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -175,12 +239,12 @@ func (d *Decoder) positiveFloat64() (float64, error) {
 	ind := floatDigits[c]
 	switch ind {
 	case invalidCharForNumber, endOfNumber:
-		return 0, badToken(c, d.offset())
+		return 0, d.badToken(c, d.offset())
 	case dotInNumber, plusInNumber, expInNumber:
-		err := badToken(c, d.offset())
+		err := d.badToken(c, d.offset())
 		return 0, errors.Wrapf(err, "leading %q", c)
 	case minusInNumber: // minus handled by caller
-		err := badToken(c, d.offset())
+		err := d.badToken(c, d.offset())
 		return 0, errors.Wrap(err, "double minus")
 	case 0:
 		if i == d.tail {
@@ -188,7 +252,7 @@ func (d *Decoder) positiveFloat64() (float64, error) {
 		}
 		c = d.buf[i]
 		if floatDigits[c] >= 0 {
-			err := badToken(c, d.offset()+1)
+			err := d.badToken(c, d.offset()+1)
 			return 0, errors.Wrap(err, "leading zero")
 		}
 	}
@@ -200,7 +264,10 @@ NonDecimal:
 		ind := floatDigits[c]
 		switch ind {
 		case invalidCharForNumber:
-			return 0, badToken(c, d.offset()+i)
+			if !d.commentStart(c) {
+				return 0, d.badToken(c, d.offset()+i)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			return float64(value), nil
@@ -223,6 +290,13 @@ NonDecimal:
 			c = d.buf[i]
 			ind := floatDigits[c]
 			switch ind {
+			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
+				return d.float64Slow()
+			case invalidCharForNumber:
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset()+i)
+				}
+				fallthrough
 			case endOfNumber:
 				if decimalPlaces > 0 && decimalPlaces < len(pow10) {
 					d.head = i
@@ -230,10 +304,6 @@ NonDecimal:
 				}
 				// too many decimal places
 				return d.float64Slow()
-			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
-				return d.float64Slow()
-			case invalidCharForNumber:
-				return 0, badToken(c, d.offset()+i)
 			}
 			decimalPlaces++
 			// Not checking for uint64SafeToMultiple10 here because
@@ -269,7 +339,7 @@ func (d *Decoder) floatSlow(size int) (float64, error) {
 		return 0, errors.Wrap(err, "number")
 	}
 
-	if err := validateFloat(str, offset); err != nil {
+	if err := d.validateFloat(str, offset); err != nil {
 		return 0, err
 	}
 
@@ -281,26 +351,25 @@ func (d *Decoder) floatSlow(size int) (float64, error) {
 	return val, nil
 }
 
-func validateFloat(str []byte, offset int) error {
+func (d *Decoder) validateFloat(str []byte, offset int) error {
 	// strconv.ParseFloat is not validating `1.` or `1.e1`
 	if len(str) == 0 {
-		// FIXME(tdakkota): use io.ErrUnexpectedEOF?
-		return errors.New("empty")
+		return classify(ErrUnexpectedEOF, errors.New("empty"))
 	}
 
 	switch c := str[0]; floatDigits[c] {
 	case dotInNumber, plusInNumber, expInNumber:
-		err := badToken(c, offset)
+		err := d.badToken(c, offset)
 		return errors.Wrapf(err, "leading %q", c)
 	case minusInNumber: // minus handled by caller
-		err := badToken(c, offset)
+		err := d.badToken(c, offset)
 		return errors.Wrap(err, "double minus")
 	case 0:
 		if len(str) >= 2 {
 			switch str[1] {
 			case 'e', 'E', '.':
 			default:
-				err := badToken(str[1], offset+1)
+				err := d.badToken(str[1], offset+1)
 				return errors.Wrap(err, "leading zero")
 			}
 		}
@@ -309,13 +378,12 @@ func validateFloat(str []byte, offset int) error {
 	dotPos := bytes.IndexByte(str, '.')
 	if dotPos != -1 {
 		if dotPos == len(str)-1 {
-			// FIXME(tdakkota): use io.ErrUnexpectedEOF?
-			return errors.New("dot as last char")
+			return classify(ErrInvalidNumber, errors.New("dot as last char"))
 		}
 		switch c := str[dotPos+1]; c {
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 		default:
-			err := badToken(c, offset+dotPos+1)
+			err := d.badToken(c, offset+dotPos+1)
 			return errors.Wrap(err, "no digit after dot")
 		}
 	}
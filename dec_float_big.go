@@ -19,7 +19,7 @@ func (d *Decoder) BigFloat() (*big.Float, error) {
 	}
 	val, _, err := big.ParseFloat(string(str), 10, uint(prec), big.ToZero)
 	if err != nil {
-		return nil, errors.Wrap(err, "float")
+		return nil, errors.Wrap(classify(ErrInvalidNumber, err), "float")
 	}
 	return val, nil
 }
@@ -33,7 +33,7 @@ func (d *Decoder) BigInt() (*big.Int, error) {
 	v := big.NewInt(0)
 	var ok bool
 	if v, ok = v.SetString(string(str), 10); !ok {
-		return nil, errors.New("invalid")
+		return nil, ErrInvalidNumber
 	}
 	return v, nil
 }
@@ -44,7 +44,10 @@ func (d *Decoder) number() ([]byte, error) {
 	for i, c := range buf {
 		switch floatDigits[c] {
 		case invalidCharForNumber:
-			return nil, badToken(c, d.offset()+i)
+			if !d.commentStart(c) {
+				return nil, d.badToken(c, d.offset()+i)
+			}
+			fallthrough
 		case endOfNumber:
 			// End of number.
 			d.head += i
@@ -59,6 +62,9 @@ func (d *Decoder) number() ([]byte, error) {
 }
 
 func (d *Decoder) numberAppend(b []byte) ([]byte, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		return d.numberAppend5(b)
+	}
 	for {
 		r, err := d.number()
 		if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"strings"
 	"testing"
 
@@ -200,7 +201,7 @@ func TestDecoderFloatUnexpectedChar(t *testing.T) {
 							err := intFn.fn(d)
 							if e := tt.errContains; e != "" {
 								a.ErrorContains(err, e)
-								v, ok := errors.Into[*badTokenErr](err)
+								v, ok := errors.Into[*SyntaxError](err)
 								if !ok {
 									return
 								}
@@ -218,6 +219,108 @@ func TestDecoderFloatUnexpectedChar(t *testing.T) {
 	}
 }
 
+func TestDecoder_SetNonFiniteStrings(t *testing.T) {
+	const input = `["NaN", "Infinity", "-Infinity", "\u004eaN", 1.5]`
+	check := func(t *testing.T, values []float64) {
+		t.Helper()
+		require.Len(t, values, 5)
+		require.True(t, math.IsNaN(values[0]))
+		require.Equal(t, []float64{math.Inf(1), math.Inf(-1)}, values[1:3])
+		require.True(t, math.IsNaN(values[3]))
+		require.Equal(t, 1.5, values[4])
+	}
+	t.Run("Float64", testBufferReader(input, func(t *testing.T, d *Decoder) {
+		d.SetNonFiniteStrings(true)
+		var values []float64
+		require.NoError(t, d.Arr(func(d *Decoder) error {
+			v, err := d.Float64()
+			values = append(values, v)
+			return err
+		}))
+		check(t, values)
+	}))
+	t.Run("Float32", testBufferReader(input, func(t *testing.T, d *Decoder) {
+		d.SetNonFiniteStrings(true)
+		var values []float64
+		require.NoError(t, d.Arr(func(d *Decoder) error {
+			v, err := d.Float32()
+			values = append(values, float64(v))
+			return err
+		}))
+		check(t, values)
+	}))
+	t.Run("Num", testBufferReader(`["NaN", "Infinity", "-Infinity", "\u004eaN", 1.5, "2"]`, func(t *testing.T, d *Decoder) {
+		d.SetNonFiniteStrings(true)
+		var nums []Num
+		require.NoError(t, d.Arr(func(d *Decoder) error {
+			n, err := d.Num()
+			nums = append(nums, n)
+			return err
+		}))
+		require.Len(t, nums, 6)
+
+		// Num.Float64 does not decode non-finite strings, Decoder does.
+		var values []float64
+		for _, n := range nums[:4] {
+			require.True(t, n.Str())
+			_, err := n.Float64()
+			require.Error(t, err, n.String())
+
+			nd := DecodeBytes(n)
+			nd.SetNonFiniteStrings(true)
+			v, err := nd.Float64()
+			require.NoError(t, err, n.String())
+			values = append(values, v)
+		}
+		for i, expected := range []float64{1.5, 2} {
+			v, err := nums[4+i].Float64()
+			require.NoError(t, err)
+			require.Equal(t, expected, v)
+			values = append(values, v)
+		}
+		check(t, values[:5])
+	}))
+	t.Run("Disabled", func(t *testing.T) {
+		for _, input := range []string{`"NaN"`, `"Infinity"`, `"-Infinity"`} {
+			_, err := DecodeStr(input).Float64()
+			require.Error(t, err, input)
+			_, err = DecodeStr(input).Num()
+			require.Error(t, err, input)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{`"nan"`, `"inf"`, `"+Infinity"`, `"NaN`, `"1"`} {
+			d := DecodeStr(input)
+			d.SetNonFiniteStrings(true)
+			_, err := d.Float64()
+			require.Error(t, err, input)
+		}
+		d := DecodeStr(`"nan"`)
+		d.SetNonFiniteStrings(true)
+		_, err := d.Float64()
+		require.ErrorIs(t, err, ErrInvalidNumber)
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		values := []float64{math.Inf(1), -0.5, math.Inf(-1)}
+		var e Encoder
+		e.SetNonFiniteMode(NonFiniteString)
+		e.Arr(func(e *Encoder) {
+			for _, v := range values {
+				e.Float64(v)
+			}
+		})
+		d := DecodeBytes(e.Bytes())
+		d.SetNonFiniteStrings(true)
+		var got []float64
+		require.NoError(t, d.Arr(func(d *Decoder) error {
+			v, err := d.Float64()
+			got = append(got, v)
+			return err
+		}))
+		require.Equal(t, values, got)
+	})
+}
+
 func BenchmarkDecoder_Float64(b *testing.B) {
 	for _, file := range []string{
 		"floats.json",
@@ -5,13 +5,10 @@ package jx
 import (
 	"io"
 	"math"
-	"strconv"
 
 	"github.com/go-faster/errors"
 )
 
-var errOverflow = strconv.ErrRange
-
 const (
 	uint8SafeToMultiple10  = uint8(0xff)/10 - 1
 	uint16SafeToMultiple10 = uint16(0xffff)/10 - 1
@@ -21,6 +18,10 @@ const (
 
 // UInt8 reads uint8.
 func (d *Decoder) UInt8() (uint8, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.uint5(8)
+		return uint8(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -37,19 +38,21 @@ func (d *Decoder) readUInt8(c byte) (uint8, error) {
 		if err == nil {
 			switch floatDigits[c] {
 			case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "digit after leading zero")
 			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "unexpected floating point character")
 			case invalidCharForNumber:
-				return 0, badToken(c, d.offset())
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset())
+				}
 			}
 		}
 		return 0, nil // single zero
 	default:
 		if ind < 0 {
-			return 0, badToken(c, d.offset()-1)
+			return 0, d.badToken(c, d.offset()-1)
 		}
 	}
 	value := uint8(ind)
@@ -58,14 +61,17 @@ func (d *Decoder) readUInt8(c byte) (uint8, error) {
 		// Iteration 0.
 		ind2 := floatDigits[d.buf[i]]
 		switch ind2 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+0)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+0)
+			err := d.badToken(d.buf[i], d.offset()+0)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+0)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1
@@ -75,14 +81,17 @@ func (d *Decoder) readUInt8(c byte) (uint8, error) {
 		// Iteration 1.
 		ind3 := floatDigits[d.buf[i]]
 		switch ind3 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+1)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+1)
+			err := d.badToken(d.buf[i], d.offset()+1)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+1)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10
@@ -93,14 +102,17 @@ func (d *Decoder) readUInt8(c byte) (uint8, error) {
 		// Iteration 2.
 		ind4 := floatDigits[d.buf[i]]
 		switch ind4 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+2)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+2)
+			err := d.badToken(d.buf[i], d.offset()+2)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+2)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100
@@ -118,14 +130,17 @@ func (d *Decoder) readUInt8(c byte) (uint8, error) {
 		for i, c := range buf {
 			ind = floatDigits[c]
 			switch ind {
-			case invalidCharForNumber:
-				return 0, badToken(c, d.offset()+i)
 			case dotInNumber,
 				expInNumber,
 				plusInNumber,
 				minusInNumber:
-				err := badToken(c, d.offset()+i)
+				err := d.badToken(c, d.offset()+i)
 				return 0, errors.Wrap(err, "unexpected floating point character")
+			case invalidCharForNumber:
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset()+i)
+				}
+				fallthrough
 			case endOfNumber:
 				d.head += i
 				return value, nil
@@ -133,7 +148,7 @@ func (d *Decoder) readUInt8(c byte) (uint8, error) {
 			if value > uint8SafeToMultiple10 {
 				value2 := (value << 3) + (value << 1) + uint8(ind)
 				if value2 < value {
-					return 0, errOverflow
+					return 0, ErrOverflow
 				}
 				value = value2
 				continue
@@ -153,6 +168,10 @@ func (d *Decoder) readUInt8(c byte) (uint8, error) {
 
 // Int8 reads int8.
 func (d *Decoder) Int8() (int8, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.int5(8)
+		return int8(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -167,7 +186,7 @@ func (d *Decoder) Int8() (int8, error) {
 			return 0, err
 		}
 		if val > math.MaxInt8+1 {
-			return 0, errOverflow
+			return 0, ErrOverflow
 		}
 		return -int8(val), nil
 	}
@@ -176,13 +195,17 @@ func (d *Decoder) Int8() (int8, error) {
 		return 0, err
 	}
 	if val > math.MaxInt8 {
-		return 0, errOverflow
+		return 0, ErrOverflow
 	}
 	return int8(val), nil
 }
 
 // UInt16 reads uint16.
 func (d *Decoder) UInt16() (uint16, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.uint5(16)
+		return uint16(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -199,19 +222,21 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 		if err == nil {
 			switch floatDigits[c] {
 			case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "digit after leading zero")
 			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "unexpected floating point character")
 			case invalidCharForNumber:
-				return 0, badToken(c, d.offset())
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset())
+				}
 			}
 		}
 		return 0, nil // single zero
 	default:
 		if ind < 0 {
-			return 0, badToken(c, d.offset()-1)
+			return 0, d.badToken(c, d.offset()-1)
 		}
 	}
 	value := uint16(ind)
@@ -220,14 +245,17 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 		// Iteration 0.
 		ind2 := floatDigits[d.buf[i]]
 		switch ind2 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+0)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+0)
+			err := d.badToken(d.buf[i], d.offset()+0)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+0)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1
@@ -237,14 +265,17 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 		// Iteration 1.
 		ind3 := floatDigits[d.buf[i]]
 		switch ind3 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+1)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+1)
+			err := d.badToken(d.buf[i], d.offset()+1)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+1)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10
@@ -255,14 +286,17 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 		// Iteration 2.
 		ind4 := floatDigits[d.buf[i]]
 		switch ind4 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+2)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+2)
+			err := d.badToken(d.buf[i], d.offset()+2)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+2)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100
@@ -274,14 +308,17 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 		// Iteration 3.
 		ind5 := floatDigits[d.buf[i]]
 		switch ind5 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+3)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+3)
+			err := d.badToken(d.buf[i], d.offset()+3)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+3)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1000
@@ -294,14 +331,17 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 		// Iteration 4.
 		ind6 := floatDigits[d.buf[i]]
 		switch ind6 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+4)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+4)
+			err := d.badToken(d.buf[i], d.offset()+4)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+4)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10000
@@ -323,14 +363,17 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 		for i, c := range buf {
 			ind = floatDigits[c]
 			switch ind {
-			case invalidCharForNumber:
-				return 0, badToken(c, d.offset()+i)
 			case dotInNumber,
 				expInNumber,
 				plusInNumber,
 				minusInNumber:
-				err := badToken(c, d.offset()+i)
+				err := d.badToken(c, d.offset()+i)
 				return 0, errors.Wrap(err, "unexpected floating point character")
+			case invalidCharForNumber:
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset()+i)
+				}
+				fallthrough
 			case endOfNumber:
 				d.head += i
 				return value, nil
@@ -338,7 +381,7 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 			if value > uint16SafeToMultiple10 {
 				value2 := (value << 3) + (value << 1) + uint16(ind)
 				if value2 < value {
-					return 0, errOverflow
+					return 0, ErrOverflow
 				}
 				value = value2
 				continue
@@ -358,6 +401,10 @@ func (d *Decoder) readUInt16(c byte) (uint16, error) {
 
 // Int16 reads int16.
 func (d *Decoder) Int16() (int16, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.int5(16)
+		return int16(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -372,7 +419,7 @@ func (d *Decoder) Int16() (int16, error) {
 			return 0, err
 		}
 		if val > math.MaxInt16+1 {
-			return 0, errOverflow
+			return 0, ErrOverflow
 		}
 		return -int16(val), nil
 	}
@@ -381,13 +428,17 @@ func (d *Decoder) Int16() (int16, error) {
 		return 0, err
 	}
 	if val > math.MaxInt16 {
-		return 0, errOverflow
+		return 0, ErrOverflow
 	}
 	return int16(val), nil
 }
 
 // UInt32 reads uint32.
 func (d *Decoder) UInt32() (uint32, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.uint5(32)
+		return uint32(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -404,19 +455,21 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		if err == nil {
 			switch floatDigits[c] {
 			case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "digit after leading zero")
 			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "unexpected floating point character")
 			case invalidCharForNumber:
-				return 0, badToken(c, d.offset())
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset())
+				}
 			}
 		}
 		return 0, nil // single zero
 	default:
 		if ind < 0 {
-			return 0, badToken(c, d.offset()-1)
+			return 0, d.badToken(c, d.offset()-1)
 		}
 	}
 	value := uint32(ind)
@@ -425,14 +478,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 0.
 		ind2 := floatDigits[d.buf[i]]
 		switch ind2 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+0)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+0)
+			err := d.badToken(d.buf[i], d.offset()+0)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+0)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1
@@ -442,14 +498,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 1.
 		ind3 := floatDigits[d.buf[i]]
 		switch ind3 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+1)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+1)
+			err := d.badToken(d.buf[i], d.offset()+1)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+1)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10
@@ -460,14 +519,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 2.
 		ind4 := floatDigits[d.buf[i]]
 		switch ind4 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+2)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+2)
+			err := d.badToken(d.buf[i], d.offset()+2)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+2)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100
@@ -479,14 +541,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 3.
 		ind5 := floatDigits[d.buf[i]]
 		switch ind5 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+3)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+3)
+			err := d.badToken(d.buf[i], d.offset()+3)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+3)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1000
@@ -499,14 +564,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 4.
 		ind6 := floatDigits[d.buf[i]]
 		switch ind6 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+4)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+4)
+			err := d.badToken(d.buf[i], d.offset()+4)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+4)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10000
@@ -520,14 +588,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 5.
 		ind7 := floatDigits[d.buf[i]]
 		switch ind7 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+5)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+5)
+			err := d.badToken(d.buf[i], d.offset()+5)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+5)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100000
@@ -542,14 +613,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 6.
 		ind8 := floatDigits[d.buf[i]]
 		switch ind8 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+6)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+6)
+			err := d.badToken(d.buf[i], d.offset()+6)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+6)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1000000
@@ -565,14 +639,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 7.
 		ind9 := floatDigits[d.buf[i]]
 		switch ind9 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+7)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+7)
+			err := d.badToken(d.buf[i], d.offset()+7)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+7)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10000000
@@ -589,14 +666,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		// Iteration 8.
 		ind10 := floatDigits[d.buf[i]]
 		switch ind10 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+8)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+8)
+			err := d.badToken(d.buf[i], d.offset()+8)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+8)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100000000
@@ -626,14 +706,17 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 		for i, c := range buf {
 			ind = floatDigits[c]
 			switch ind {
-			case invalidCharForNumber:
-				return 0, badToken(c, d.offset()+i)
 			case dotInNumber,
 				expInNumber,
 				plusInNumber,
 				minusInNumber:
-				err := badToken(c, d.offset()+i)
+				err := d.badToken(c, d.offset()+i)
 				return 0, errors.Wrap(err, "unexpected floating point character")
+			case invalidCharForNumber:
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset()+i)
+				}
+				fallthrough
 			case endOfNumber:
 				d.head += i
 				return value, nil
@@ -641,7 +724,7 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 			if value > uint32SafeToMultiple10 {
 				value2 := (value << 3) + (value << 1) + uint32(ind)
 				if value2 < value {
-					return 0, errOverflow
+					return 0, ErrOverflow
 				}
 				value = value2
 				continue
@@ -661,6 +744,10 @@ func (d *Decoder) readUInt32(c byte) (uint32, error) {
 
 // Int32 reads int32.
 func (d *Decoder) Int32() (int32, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.int5(32)
+		return int32(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -675,7 +762,7 @@ func (d *Decoder) Int32() (int32, error) {
 			return 0, err
 		}
 		if val > math.MaxInt32+1 {
-			return 0, errOverflow
+			return 0, ErrOverflow
 		}
 		return -int32(val), nil
 	}
@@ -684,13 +771,17 @@ func (d *Decoder) Int32() (int32, error) {
 		return 0, err
 	}
 	if val > math.MaxInt32 {
-		return 0, errOverflow
+		return 0, ErrOverflow
 	}
 	return int32(val), nil
 }
 
 // UInt64 reads uint64.
 func (d *Decoder) UInt64() (uint64, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.uint5(64)
+		return uint64(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -707,19 +798,21 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		if err == nil {
 			switch floatDigits[c] {
 			case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "digit after leading zero")
 			case dotInNumber, expInNumber, plusInNumber, minusInNumber:
-				err := badToken(c, d.offset())
+				err := d.badToken(c, d.offset())
 				return 0, errors.Wrap(err, "unexpected floating point character")
 			case invalidCharForNumber:
-				return 0, badToken(c, d.offset())
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset())
+				}
 			}
 		}
 		return 0, nil // single zero
 	default:
 		if ind < 0 {
-			return 0, badToken(c, d.offset()-1)
+			return 0, d.badToken(c, d.offset()-1)
 		}
 	}
 	value := uint64(ind)
@@ -728,14 +821,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 0.
 		ind2 := floatDigits[d.buf[i]]
 		switch ind2 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+0)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+0)
+			err := d.badToken(d.buf[i], d.offset()+0)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+0)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1
@@ -745,14 +841,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 1.
 		ind3 := floatDigits[d.buf[i]]
 		switch ind3 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+1)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+1)
+			err := d.badToken(d.buf[i], d.offset()+1)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+1)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10
@@ -763,14 +862,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 2.
 		ind4 := floatDigits[d.buf[i]]
 		switch ind4 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+2)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+2)
+			err := d.badToken(d.buf[i], d.offset()+2)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+2)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100
@@ -782,14 +884,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 3.
 		ind5 := floatDigits[d.buf[i]]
 		switch ind5 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+3)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+3)
+			err := d.badToken(d.buf[i], d.offset()+3)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+3)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1000
@@ -802,14 +907,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 4.
 		ind6 := floatDigits[d.buf[i]]
 		switch ind6 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+4)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+4)
+			err := d.badToken(d.buf[i], d.offset()+4)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+4)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10000
@@ -823,14 +931,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 5.
 		ind7 := floatDigits[d.buf[i]]
 		switch ind7 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+5)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+5)
+			err := d.badToken(d.buf[i], d.offset()+5)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+5)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100000
@@ -845,14 +956,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 6.
 		ind8 := floatDigits[d.buf[i]]
 		switch ind8 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+6)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+6)
+			err := d.badToken(d.buf[i], d.offset()+6)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+6)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 1000000
@@ -868,14 +982,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 7.
 		ind9 := floatDigits[d.buf[i]]
 		switch ind9 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+7)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+7)
+			err := d.badToken(d.buf[i], d.offset()+7)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+7)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 10000000
@@ -892,14 +1009,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		// Iteration 8.
 		ind10 := floatDigits[d.buf[i]]
 		switch ind10 {
-		case invalidCharForNumber:
-			return 0, badToken(d.buf[i], d.offset()+8)
 		case dotInNumber,
 			expInNumber,
 			plusInNumber,
 			minusInNumber:
-			err := badToken(d.buf[i], d.offset()+8)
+			err := d.badToken(d.buf[i], d.offset()+8)
 			return 0, errors.Wrap(err, "unexpected floating point character")
+		case invalidCharForNumber:
+			if !d.commentStart(d.buf[i]) {
+				return 0, d.badToken(d.buf[i], d.offset()+8)
+			}
+			fallthrough
 		case endOfNumber:
 			d.head = i
 			value *= 100000000
@@ -929,14 +1049,17 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 		for i, c := range buf {
 			ind = floatDigits[c]
 			switch ind {
-			case invalidCharForNumber:
-				return 0, badToken(c, d.offset()+i)
 			case dotInNumber,
 				expInNumber,
 				plusInNumber,
 				minusInNumber:
-				err := badToken(c, d.offset()+i)
+				err := d.badToken(c, d.offset()+i)
 				return 0, errors.Wrap(err, "unexpected floating point character")
+			case invalidCharForNumber:
+				if !d.commentStart(c) {
+					return 0, d.badToken(c, d.offset()+i)
+				}
+				fallthrough
 			case endOfNumber:
 				d.head += i
 				return value, nil
@@ -944,7 +1067,7 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 			if value > uint64SafeToMultiple10 {
 				value2 := (value << 3) + (value << 1) + uint64(ind)
 				if value2 < value {
-					return 0, errOverflow
+					return 0, ErrOverflow
 				}
 				value = value2
 				continue
@@ -964,6 +1087,10 @@ func (d *Decoder) readUInt64(c byte) (uint64, error) {
 
 // Int64 reads int64.
 func (d *Decoder) Int64() (int64, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		v, err := d.int5(64)
+		return int64(v), err
+	}
 	c, err := d.more()
 	if err != nil {
 		return 0, err
@@ -978,7 +1105,7 @@ func (d *Decoder) Int64() (int64, error) {
 			return 0, err
 		}
 		if val > math.MaxInt64+1 {
-			return 0, errOverflow
+			return 0, ErrOverflow
 		}
 		return -int64(val), nil
 	}
@@ -987,7 +1114,7 @@ func (d *Decoder) Int64() (int64, error) {
 		return 0, err
 	}
 	if val > math.MaxInt64 {
-		return 0, errOverflow
+		return 0, ErrOverflow
 	}
 	return int64(val), nil
 }
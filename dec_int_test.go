@@ -211,7 +211,7 @@ func TestDecoderIntUnexpectedChar(t *testing.T) {
 							err := intFn.fn(d)
 							if e := tt.errString; e != "" {
 								a.EqualError(err, e)
-								v, ok := errors.Into[*badTokenErr](err)
+								v, ok := errors.Into[*SyntaxError](err)
 								if !ok {
 									return
 								}
@@ -0,0 +1,593 @@
+package jx
+
+import (
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/go-faster/errors"
+)
+
+var types5 []Type
+
+func init() {
+	types5 = make([]Type, len(types))
+	copy(types5, types)
+	types5['\''] = String
+	types5['+'] = Number
+	types5['.'] = Number
+	types5['I'] = Number
+	types5['N'] = Number
+}
+
+// identSet marks bytes of JSON5 identifier: 1 for any position, 2 for
+// non-first position only.
+//
+// Every non-ASCII byte is accepted as part of identifier.
+var identSet = [256]byte{}
+
+// num5Set marks bytes of JSON5 number token.
+var num5Set = [256]byte{
+	'+': 1, '-': 1, '.': 1,
+}
+
+func init() {
+	for c := 'a'; c <= 'z'; c++ {
+		identSet[c] = 1
+		identSet[c-'a'+'A'] = 1
+		num5Set[c] = 1
+		num5Set[c-'a'+'A'] = 1
+	}
+	for c := '0'; c <= '9'; c++ {
+		identSet[c] = 2
+		num5Set[c] = 1
+	}
+	for c := 0x80; c <= 0xff; c++ {
+		identSet[c] = 1
+	}
+	identSet['_'] = 1
+	identSet['$'] = 1
+}
+
+// skipExtra skips comment or JSON5 whitespace starting with consumed c.
+//
+// Reports false if c starts neither.
+func (d *Decoder) skipExtra(c byte) (bool, error) {
+	switch c {
+	case '/':
+		return true, d.skipComment()
+	case '\v', '\f':
+		return d.opts.syntax == SyntaxJSON5, nil
+	default:
+		return false, nil
+	}
+}
+
+// str5 reads JSON5 string.
+func (d *Decoder) str5(v value) (value, error) {
+	c, err := d.more()
+	if err != nil {
+		return value{}, err
+	}
+	if c != '"' && c != '\'' {
+		return value{}, d.badToken(c, d.offset()-1)
+	}
+	if !v.raw {
+		return d.str5Body(c, v)
+	}
+	// String is always copied, so reuse scratch buffer.
+	v, err = d.str5Body(c, value{buf: d.scratch[:0]})
+	d.scratch = v.buf[:0]
+	return v, err
+}
+
+// str5Body reads JSON5 string ending with quote.
+//
+// Assumes first quote was consumed.
+func (d *Decoder) str5Body(quote byte, v value) (value, error) {
+	// checked is length of v.buf prefix that is already checked
+	// to be valid UTF-8.
+	checked := len(v.buf)
+	for {
+		c, err := d.byte()
+		if err != nil {
+			return value{}, err
+		}
+		switch c {
+		case quote:
+			return d.checkUTF8(v, checked, d.offset()-1)
+		case '\\':
+			if v, err = d.checkUTF8(v, checked, d.offset()-1); err != nil {
+				return v, err
+			}
+			if v, err = d.escaped5(v); err != nil {
+				return v, errors.Wrap(err, "escape")
+			}
+			checked = len(v.buf)
+		case '\n', '\r':
+			return v, d.badToken(c, d.offset()-1)
+		default:
+			v.buf = append(v.buf, c)
+		}
+	}
+}
+
+// escaped5 reads JSON5 escape sequence.
+//
+// Assumes backslash was consumed.
+func (d *Decoder) escaped5(v value) (value, error) {
+	c, err := d.byte()
+	if err != nil {
+		return value{}, err
+	}
+	switch c {
+	case 'b', 'f', 'n', 'r', 't', 'u', '"', '\\', '/':
+		return d.escapedChar(v, c)
+	case 'v':
+		v.buf = append(v.buf, '\v')
+	case 'x':
+		var r rune
+		for i := 0; i < 2; i++ {
+			c, err := d.byte()
+			if err != nil {
+				return value{}, err
+			}
+			val := hexSet[c]
+			if val == 0 {
+				return value{}, classify(ErrInvalidEscape, d.badToken(c, d.offset()-1))
+			}
+			r = r*16 + rune(val-1)
+		}
+		v = v.rune(r)
+	case '0':
+		if c, err := d.peek(); err == nil && c >= '0' && c <= '9' {
+			return value{}, classify(ErrInvalidEscape, d.badToken(c, d.offset()))
+		}
+		v.buf = append(v.buf, 0)
+	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return value{}, classify(ErrInvalidEscape, d.badToken(c, d.offset()-1))
+	case '\n':
+		// Line continuation.
+	case '\r':
+		// Line continuation, possibly CRLF.
+		if c, err := d.peek(); err == nil && c == '\n' {
+			d.head++
+		}
+	default:
+		if c >= utf8.RuneSelf {
+			// Escaped non-ASCII character is the character itself.
+			d.unread()
+			return v, nil
+		}
+		v.buf = append(v.buf, c)
+	}
+	return v, nil
+}
+
+// key reads object key.
+func (d *Decoder) key(v value) (value, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		// Key must outlive callback, so it is never stored in scratch.
+		return d.key5(value{buf: v.buf})
+	}
+	return d.str(v)
+}
+
+// key5 reads JSON5 object key, which is string or identifier.
+func (d *Decoder) key5(v value) (value, error) {
+	c, err := d.more()
+	if err != nil {
+		return value{}, err
+	}
+	if c == '"' || c == '\'' {
+		return d.str5Body(c, v)
+	}
+	if identSet[c] != 1 {
+		err := d.badToken(c, d.offset()-1)
+		return value{}, errors.Wrap(err, "string or identifier expected")
+	}
+	v.buf = append(v.buf, c)
+	for {
+		c, err := d.peek()
+		if err == io.EOF {
+			return v, nil
+		}
+		if err != nil {
+			return value{}, err
+		}
+		if identSet[c] == 0 {
+			return v, nil
+		}
+		v.buf = append(v.buf, c)
+		d.head++
+	}
+}
+
+// num5 reads JSON5 number and appends it to b as json number.
+//
+// Returns non-zero special value and b as is for NaN and Infinity.
+func (d *Decoder) num5(b []byte) (_ []byte, special float64, _ error) {
+	if _, err := d.more(); err != nil {
+		return b, 0, err
+	}
+	d.unread()
+
+	var (
+		offset = d.offset()
+		tokBuf [32]byte
+		tok    = tokBuf[:0]
+	)
+	for {
+		c, err := d.peek()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return b, 0, err
+		}
+		if num5Set[c] == 0 {
+			break
+		}
+		tok = append(tok, c)
+		d.head++
+	}
+	if len(tok) == 0 {
+		c, err := d.peek()
+		if err != nil {
+			return b, 0, err
+		}
+		return b, 0, d.badToken(c, offset)
+	}
+	// bad returns error for i-th byte of token.
+	bad := func(i int) error {
+		if i >= len(tok) {
+			return classify(ErrInvalidNumber, errors.Errorf("unexpected end of number at %d", offset+i))
+		}
+		return classify(ErrInvalidNumber, d.badToken(tok[i], offset+i))
+	}
+
+	i := 0
+	neg := false
+	switch tok[0] {
+	case '-':
+		neg = true
+		i++
+	case '+':
+		i++
+	}
+	switch string(tok[i:]) {
+	case "Infinity":
+		if neg {
+			return b, math.Inf(-1), nil
+		}
+		return b, math.Inf(1), nil
+	case "NaN":
+		return b, math.NaN(), nil
+	}
+	if neg {
+		b = append(b, '-')
+	}
+
+	// Hexadecimal integer.
+	if len(tok)-i > 2 && tok[i] == '0' && (tok[i+1] == 'x' || tok[i+1] == 'X') {
+		digits := tok[i+2:]
+		for j, c := range digits {
+			if hexSet[c] == 0 {
+				return b, 0, bad(i + 2 + j)
+			}
+		}
+		if len(digits) <= 16 {
+			v, err := strconv.ParseUint(string(digits), 16, 64)
+			if err != nil {
+				return b, 0, classify(ErrInvalidNumber, err)
+			}
+			return strconv.AppendUint(b, v, 10), 0, nil
+		}
+		v, _ := new(big.Int).SetString(string(digits), 16)
+		return v.Append(b, 10), 0, nil
+	}
+
+	// Decimal number, integer or fractional part may be empty.
+	isDigit := func(i int) bool { return i < len(tok) && tok[i] >= '0' && tok[i] <= '9' }
+	intStart := i
+	for isDigit(i) {
+		i++
+	}
+	intPart := tok[intStart:i]
+	if len(intPart) > 1 && intPart[0] == '0' {
+		return b, 0, errors.Wrap(bad(intStart+1), "digit after leading zero")
+	}
+	var fracPart []byte
+	if i < len(tok) && tok[i] == '.' {
+		i++
+		fracStart := i
+		for isDigit(i) {
+			i++
+		}
+		fracPart = tok[fracStart:i]
+	}
+	if len(intPart) == 0 && len(fracPart) == 0 {
+		return b, 0, bad(intStart)
+	}
+	expStart := i
+	if i < len(tok) && (tok[i] == 'e' || tok[i] == 'E') {
+		i++
+		if i < len(tok) && (tok[i] == '+' || tok[i] == '-') {
+			i++
+		}
+		if !isDigit(i) {
+			return b, 0, bad(i)
+		}
+		for isDigit(i) {
+			i++
+		}
+	}
+	if i != len(tok) {
+		return b, 0, bad(i)
+	}
+
+	if len(intPart) == 0 {
+		b = append(b, '0')
+	}
+	b = append(b, intPart...)
+	if len(fracPart) > 0 {
+		b = append(b, '.')
+		b = append(b, fracPart...)
+	}
+	return append(b, tok[expStart:]...), 0, nil
+}
+
+var errNonFinite = classify(ErrInvalidNumber, errors.New("non-finite number"))
+
+// numberAppend5 is numberAppend for JSON5 numbers.
+func (d *Decoder) numberAppend5(b []byte) ([]byte, error) {
+	b, special, err := d.num5(b)
+	if err != nil {
+		return b, err
+	}
+	if special != 0 {
+		return b, errNonFinite
+	}
+	return b, nil
+}
+
+// float5 reads JSON5 number as float.
+func (d *Decoder) float5(size int) (float64, error) {
+	var buf [32]byte
+	str, special, err := d.num5(buf[:0])
+	if err != nil {
+		return 0, err
+	}
+	if special != 0 {
+		return special, nil
+	}
+	return strconv.ParseFloat(string(str), size)
+}
+
+// int5 reads JSON5 number as signed integer.
+func (d *Decoder) int5(bitSize int) (int64, error) {
+	var buf [32]byte
+	str, err := d.numberAppend5(buf[:0])
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(string(str), 10, bitSize)
+	if err != nil {
+		return 0, intErr5(err)
+	}
+	return v, nil
+}
+
+// uint5 reads JSON5 number as unsigned integer.
+func (d *Decoder) uint5(bitSize int) (uint64, error) {
+	var buf [32]byte
+	str, err := d.numberAppend5(buf[:0])
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(string(str), 10, bitSize)
+	if err != nil {
+		return 0, intErr5(err)
+	}
+	return v, nil
+}
+
+func intErr5(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
+	}
+	return classify(ErrInvalidNumber, err)
+}
+
+// raw5 reads JSON5 value and returns it re-encoded as json.
+//
+// Returned value is not overwritten by subsequent reads.
+func (d *Decoder) raw5() (Raw, error) {
+	var w Writer
+	if err := d.value5(&w); err != nil {
+		return nil, errors.Wrap(err, "skip")
+	}
+	return w.Buf, nil
+}
+
+// value5 reads JSON5 value, writing it as json to w if w is not nil.
+func (d *Decoder) value5(w *Writer) error {
+	c, err := d.next()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '"', '\'':
+		v, err := d.str5Body(c, value{buf: d.scratch[:0]})
+		if err != nil {
+			return errors.Wrap(err, "str")
+		}
+		d.scratch = v.buf[:0]
+		if w != nil {
+			w.ByteStr(v.buf)
+		}
+	case 'n':
+		d.unread()
+		if err := d.Null(); err != nil {
+			return err
+		}
+		if w != nil {
+			w.Null()
+		}
+	case 't', 'f':
+		d.unread()
+		v, err := d.Bool()
+		if err != nil {
+			return err
+		}
+		if w != nil {
+			w.Bool(v)
+		}
+	case '[':
+		if err := d.arr5(w); err != nil {
+			return errors.Wrap(err, "array")
+		}
+	case '{':
+		if err := d.obj5(w); err != nil {
+			return errors.Wrap(err, "object")
+		}
+	default:
+		if types5[c] != Number {
+			return d.badToken(c, d.offset()-1)
+		}
+		d.unread()
+		offset := d.offset()
+		num, special, err := d.num5(d.scratch[:0])
+		if err != nil {
+			return err
+		}
+		d.scratch = num[:0]
+		if w == nil {
+			return nil
+		}
+		if special != 0 {
+			err := errors.Wrapf(d.badToken(c, offset), "%v is not representable in json", special)
+			return classify(ErrInvalidNumber, err)
+		}
+		w.Raw(num)
+	}
+	return nil
+}
+
+// arr5 reads JSON5 array.
+//
+// Assumes first bracket was consumed.
+func (d *Decoder) arr5(w *Writer) error {
+	if err := d.incDepth(); err != nil {
+		return errors.Wrap(err, "inc")
+	}
+	if w != nil {
+		w.ArrStart()
+	}
+	c, err := d.more()
+	if err != nil {
+		return errors.Wrap(err, `value or "]" expected`)
+	}
+	if c != ']' {
+		for {
+			d.unread()
+			if err := d.value5(w); err != nil {
+				return err
+			}
+			if c, err = d.more(); err != nil {
+				return errors.Wrap(err, `"," or "]" expected`)
+			}
+			if c != ',' {
+				break
+			}
+			if c, err = d.more(); err != nil {
+				return errors.Wrap(err, `value or "]" expected`)
+			}
+			if c == ']' {
+				break
+			}
+			if w != nil {
+				w.Comma()
+			}
+		}
+		if c != ']' {
+			err := d.badToken(c, d.offset()-1)
+			return errors.Wrap(err, `"," or "]" expected`)
+		}
+	}
+	if w != nil {
+		w.ArrEnd()
+	}
+	return d.decDepth()
+}
+
+// obj5 reads JSON5 object.
+//
+// Assumes first bracket was consumed.
+func (d *Decoder) obj5(w *Writer) error {
+	if err := d.incDepth(); err != nil {
+		return errors.Wrap(err, "inc")
+	}
+	d.objStart()
+	if w != nil {
+		w.ObjStart()
+	}
+	c, err := d.more()
+	if err != nil {
+		return errors.Wrap(err, `key or "}" expected`)
+	}
+	if c != '}' {
+		for {
+			d.unread()
+			offset := d.offset()
+			k, err := d.key5(value{buf: d.scratch[:0]})
+			if err != nil {
+				return errors.Wrap(err, "read field name")
+			}
+			d.scratch = k.buf[:0]
+			if d.opts.rejectDupKeys {
+				if err := d.checkKey(k.buf, offset); err != nil {
+					return err
+				}
+			}
+			if w != nil {
+				w.ByteStr(k.buf)
+				w.byte(':')
+			}
+			if err := d.consume(':'); err != nil {
+				return errors.Wrap(err, `":" expected`)
+			}
+			if err := d.skipSpace(); err != nil {
+				return err
+			}
+			if err := d.value5(w); err != nil {
+				return err
+			}
+			if c, err = d.more(); err != nil {
+				return errors.Wrap(err, `"," or "}" expected`)
+			}
+			if c != ',' {
+				break
+			}
+			if c, err = d.more(); err != nil {
+				return errors.Wrap(err, `key or "}" expected`)
+			}
+			if c == '}' {
+				break
+			}
+			if w != nil {
+				w.Comma()
+			}
+		}
+		if c != '}' {
+			err := d.badToken(c, d.offset()-1)
+			return errors.Wrap(err, `"," or "}" expected`)
+		}
+	}
+	if w != nil {
+		w.ObjEnd()
+	}
+	return d.decDepth()
+}
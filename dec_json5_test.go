@@ -0,0 +1,259 @@
+package jx
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_JSON5(t *testing.T) {
+	decoders := []struct {
+		Name   string
+		Decode func(d *Decoder) error
+	}{
+		{"Validate", (*Decoder).Validate},
+		{"Skip", (*Decoder).Skip},
+		{"Raw", decoderOnlyError((*Decoder).Raw)},
+		{"Crawl", crawlValue},
+		{"Iter", iterValue},
+	}
+	t.Run("Valid", func(t *testing.T) {
+		for _, input := range []string{
+			`{unquoted: 1}`,
+			`{$_id0: 1, ünicode: 2}`,
+			`{'single': 'quoted'}`,
+			`'it\'s "quoted"'`,
+			`"\x41\v\0\q"`,
+			"'line \\\ncontinuation'",
+			`0x1F`,
+			`-0xff`,
+			`.5`,
+			`5.`,
+			`+1`,
+			`+.5e-3`,
+			`Infinity`,
+			`-Infinity`,
+			`+Infinity`,
+			`NaN`,
+			"\v\f1\v",
+			`// comment
+{
+	key: 'value', // trailing comma
+	arr: [0x10, .5, 5., +1,],
+}`,
+		} {
+			for _, dec := range decoders {
+				dec := dec
+				if dec.Name == "Raw" && (strings.Contains(input, "Infinity") || strings.Contains(input, "NaN")) {
+					continue
+				}
+				t.Run(dec.Name, testBufferReader(input, func(t *testing.T, d *Decoder) {
+					d.SetSyntax(SyntaxJSON5)
+					require.NoError(t, dec.Decode(d))
+				}))
+			}
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{
+			`{0key: 1}`,
+			`{'a' 1}`,
+			`'unterminated`,
+			`"mixed'`,
+			"'new\nline'",
+			`"\1"`,
+			`"\xZZ"`,
+			`0x`,
+			`0xZ`,
+			`.`,
+			`+`,
+			`01`,
+			`1e`,
+			`1.2.3`,
+			`Infinit`,
+			`undefined`,
+			`[1,,]`,
+		} {
+			for _, dec := range decoders {
+				dec := dec
+				t.Run(dec.Name, testBufferReader(input, func(t *testing.T, d *Decoder) {
+					d.SetSyntax(SyntaxJSON5)
+					require.Error(t, func() error {
+						if err := dec.Decode(d); err != nil {
+							return err
+						}
+						return d.Validate()
+					}())
+				}))
+			}
+		}
+	})
+}
+
+func TestDecoder_JSON5Raw(t *testing.T) {
+	for i, tt := range []struct {
+		Input  string
+		Output string
+	}{
+		{`{unquoted: 'single', "double": "x",}`, `{"unquoted":"single","double":"x"}`},
+		{`['it\'s', "say \"hi\"", '\x41B']`, `["it's","say \"hi\"","AB"]`},
+		{`[0x1F, -0XfF, .5, 5., +1, -.5e+3, 0]`, `[31,-255,0.5,5,1,-0.5e+3,0]`},
+		{`0xFFFFFFFFFFFFFFFFFF`, `4722366482869645213695`},
+		{`/* c */ [ true , false , null , ] // c`, `[true,false,null]`},
+		{`{a: {b: [{}, [],],},}`, `{"a":{"b":[{},[]]}}`},
+		{"'line \\\r\ncontinuation'", `"line continuation"`},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+			d.SetSyntax(SyntaxJSON5)
+			raw, err := d.Raw()
+			require.NoError(t, err)
+			require.Equal(t, tt.Output, raw.String())
+
+			// Re-encoded value is strict json.
+			require.NoError(t, DecodeBytes(raw).Validate())
+		}))
+	}
+	t.Run("NonFinite", func(t *testing.T) {
+		for _, input := range []string{`NaN`, `[Infinity]`, `{a: -Infinity}`} {
+			d := DecodeStr(input)
+			d.SetSyntax(SyntaxJSON5)
+			_, err := d.Raw()
+			require.ErrorIs(t, err, ErrInvalidNumber, input)
+			var serr *SyntaxError
+			require.ErrorAs(t, err, &serr, input)
+			require.Equal(t, strings.IndexAny(input, "-IN"), serr.Offset, input)
+		}
+	})
+	t.Run("Retain", testBufferReader(`[1, 'a'] {b: 2}`, func(t *testing.T, d *Decoder) {
+		d.SetSyntax(SyntaxJSON5)
+		first, err := d.Raw()
+		require.NoError(t, err)
+		second, err := d.Raw()
+		require.NoError(t, err)
+		require.Equal(t, `[1,"a"]`, first.String())
+		require.Equal(t, `{"b":2}`, second.String())
+	}))
+}
+
+func TestDecoder_JSON5Values(t *testing.T) {
+	json5 := func(input string) *Decoder {
+		d := DecodeStr(input)
+		d.SetSyntax(SyntaxJSON5)
+		return d
+	}
+	t.Run("Str", func(t *testing.T) {
+		for _, tt := range []struct {
+			Input  string
+			Output string
+		}{
+			{`'single'`, "single"},
+			{`"double"`, "double"},
+			{`'\'"'`, `'"`},
+			{`'\v\0\x7e\/'`, "\v\x00~/"},
+			{`'\a\c'`, "ac"},
+			{`'\😀'`, "😀"},
+		} {
+			s, err := json5(tt.Input).Str()
+			require.NoError(t, err, tt.Input)
+			require.Equal(t, tt.Output, s, tt.Input)
+		}
+	})
+	t.Run("Float64", func(t *testing.T) {
+		for _, tt := range []struct {
+			Input  string
+			Output float64
+		}{
+			{`0x10`, 16},
+			{`.5`, 0.5},
+			{`5.`, 5},
+			{`+1.5`, 1.5},
+			{`-.5e1`, -5},
+			{`Infinity`, math.Inf(1)},
+			{`+Infinity`, math.Inf(1)},
+			{`-Infinity`, math.Inf(-1)},
+		} {
+			v, err := json5(tt.Input).Float64()
+			require.NoError(t, err, tt.Input)
+			require.Equal(t, tt.Output, v, tt.Input)
+		}
+		v, err := json5(`NaN`).Float64()
+		require.NoError(t, err)
+		require.True(t, math.IsNaN(v))
+	})
+	t.Run("Int", func(t *testing.T) {
+		v, err := json5(`0x7F`).Int8()
+		require.NoError(t, err)
+		require.Equal(t, int8(127), v)
+
+		v64, err := json5(`-0x8000000000000000`).Int64()
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MinInt64), v64)
+
+		u, err := json5(`+10`).UInt16()
+		require.NoError(t, err)
+		require.Equal(t, uint16(10), u)
+
+		_, err = json5(`0x80`).Int8()
+		require.ErrorIs(t, err, ErrOverflow)
+		_, err = json5(`1.5`).Int()
+		require.ErrorIs(t, err, ErrInvalidNumber)
+		_, err = json5(`NaN`).Int()
+		require.ErrorIs(t, err, ErrInvalidNumber)
+		_, err = json5(`-1`).UInt()
+		require.ErrorIs(t, err, ErrInvalidNumber)
+	})
+	t.Run("Num", func(t *testing.T) {
+		n, err := json5(`0x1F`).Num()
+		require.NoError(t, err)
+		require.Equal(t, "31", n.String())
+		_, err = json5(`'.5'`).Num()
+		require.Error(t, err)
+		n, err = json5(`'5'`).Num()
+		require.NoError(t, err)
+		require.Equal(t, `"5"`, n.String())
+		_, err = json5(`NaN`).Num()
+		require.Error(t, err)
+	})
+	t.Run("BigInt", func(t *testing.T) {
+		v, err := json5(`0xFFFFFFFFFFFFFFFFFF`).BigInt()
+		require.NoError(t, err)
+		require.Equal(t, "4722366482869645213695", v.String())
+	})
+	t.Run("Obj", func(t *testing.T) {
+		var keys []string
+		d := json5(`{a: 1, 'b': 2, "c": 3, $d_1: 4}`)
+		require.NoError(t, d.Obj(func(d *Decoder, key string) error {
+			keys = append(keys, key)
+			return d.Skip()
+		}))
+		require.Equal(t, []string{"a", "b", "c", "$d_1"}, keys)
+	})
+	t.Run("DuplicateKeys", func(t *testing.T) {
+		d := json5(`{a: 1, 'a': 2}`)
+		d.SetRejectDuplicateKeys(true)
+		var dupErr *DuplicateKeyError
+		require.True(t, errors.As(d.Validate(), &dupErr))
+		require.Equal(t, "a", dupErr.Key)
+		require.Equal(t, 7, dupErr.Offset)
+	})
+	t.Run("Next", func(t *testing.T) {
+		for _, tt := range []struct {
+			Input string
+			Type  Type
+		}{
+			{`'a'`, String},
+			{`+1`, Number},
+			{`.5`, Number},
+			{`Infinity`, Number},
+			{`NaN`, Number},
+		} {
+			require.Equal(t, tt.Type, json5(tt.Input).Next(), tt.Input)
+			require.Equal(t, Invalid, DecodeStr(tt.Input).Next(), tt.Input)
+		}
+	})
+}
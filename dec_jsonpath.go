@@ -0,0 +1,596 @@
+package jx
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// JSONPath is compiled JSONPath query.
+//
+// Supported subset is root ($), child (.name, ['name']), wildcard (.*, [*]),
+// recursive descent (..name, ..*, ..[0]), non-negative array index and
+// slice ([0], [1:5:2]) and filter on scalars ([?(@.price < 10)], [?@.id]).
+//
+// Filter compares value of relative path, like @, @.a or @['a'][0], with
+// number, string, true, false or null literal using ==, !=, <, <=, > or >=.
+// Filter without comparison tests that value exists.
+type JSONPath struct {
+	expr string
+	segs []pathSegment
+}
+
+type pathSegment struct {
+	descendant bool // ".."
+	sel        pathSelector
+}
+
+type pathSelectorKind byte
+
+const (
+	pathName pathSelectorKind = iota
+	pathWildcard
+	pathIndex
+	pathSlice
+	pathFilter
+)
+
+type pathSelector struct {
+	kind pathSelectorKind
+	name string
+	// start is index for pathIndex and start of pathSlice.
+	start int
+	// end of pathSlice, -1 if not set.
+	end    int
+	step   int
+	filter *pathFilterExpr
+}
+
+// matchIndex reports whether array element i is selected.
+func (s pathSelector) matchIndex(i int) bool {
+	switch s.kind {
+	case pathWildcard:
+		return true
+	case pathIndex:
+		return i == s.start
+	case pathSlice:
+		return i >= s.start && (s.end < 0 || i < s.end) && (i-s.start)%s.step == 0
+	default:
+		return false
+	}
+}
+
+// pathFilterExpr is filter expression, like @.price < 10.
+type pathFilterExpr struct {
+	ptr string // relative path as json pointer
+	op  string // empty for existence test
+
+	// Literal of type typ, decoded at parse time.
+	typ  Type
+	num  float64
+	str  string
+	bool bool
+}
+
+// ParseJSONPath compiles JSONPath query.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	p := pathParser{s: expr}
+	segs, err := p.parse()
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %q", expr)
+	}
+	return &JSONPath{expr: expr, segs: segs}, nil
+}
+
+// String returns query expression.
+func (p *JSONPath) String() string {
+	return p.expr
+}
+
+// Query reads single value from decoder, calling f for every matched value.
+//
+// Everything else is skipped. Matched value is valid only until f returns.
+func (p *JSONPath) Query(d *Decoder, f func(raw Raw) error) error {
+	return p.eval(d, p.segs, f)
+}
+
+func (p *JSONPath) eval(d *Decoder, segs []pathSegment, f func(raw Raw) error) error {
+	if len(segs) == 0 {
+		if err := d.skipSpace(); err != nil {
+			return err
+		}
+		raw, err := d.Raw()
+		if err != nil {
+			return err
+		}
+		return f(raw)
+	}
+	seg, rest := segs[0], segs[1:]
+	if seg.descendant {
+		return p.evalDescendant(d, seg.sel, rest, segs, f)
+	}
+	return p.evalChildren(d, seg.sel, func(d *Decoder, match bool) error {
+		if !match {
+			return d.Skip()
+		}
+		return p.eval(d, rest, f)
+	})
+}
+
+// evalDescendant evaluates recursive descent segment.
+func (p *JSONPath) evalDescendant(d *Decoder, sel pathSelector, rest, segs []pathSegment, f func(raw Raw) error) error {
+	if d.reader != nil || d.opts.syntax == SyntaxJSON5 {
+		// Matched children are read twice, so copy whole value once and
+		// descend over it.
+		raw, err := d.rawStable()
+		if err != nil {
+			return err
+		}
+		d = d.rawDecoder(raw)
+		// JSON5 value is re-encoded as json.
+		if d.opts.syntax == SyntaxJSON5 {
+			d.opts.syntax = SyntaxStrict
+		}
+	}
+	return p.evalChildren(d, sel, func(d *Decoder, match bool) error {
+		if !match {
+			// Continue descent.
+			return p.eval(d, segs, f)
+		}
+		// Child is both matched and descended into.
+		raw, err := d.rawStable()
+		if err != nil {
+			return err
+		}
+		if len(rest) == 0 {
+			err = f(raw)
+		} else {
+			err = p.eval(d.rawDecoder(raw), rest, f)
+		}
+		if err != nil {
+			return err
+		}
+		return p.eval(d.rawDecoder(raw), segs, f)
+	})
+}
+
+// evalChildren calls f for every child of current array or object,
+// reporting whether child is selected. Scalar values are skipped.
+func (p *JSONPath) evalChildren(d *Decoder, sel pathSelector, f func(d *Decoder, match bool) error) error {
+	switch d.Next() {
+	case Object:
+		iter, err := d.ObjIter()
+		if err != nil {
+			return err
+		}
+		for iter.Next() {
+			if err := p.evalChild(d, sel, sel.kind == pathWildcard || sel.kind == pathName && string(iter.Key()) == sel.name, f); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	case Array:
+		iter, err := d.ArrIter()
+		if err != nil {
+			return err
+		}
+		for i := 0; iter.Next(); i++ {
+			if err := p.evalChild(d, sel, sel.matchIndex(i), f); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	default:
+		return d.Skip()
+	}
+}
+
+func (p *JSONPath) evalChild(d *Decoder, sel pathSelector, match bool, f func(d *Decoder, match bool) error) error {
+	if sel.kind != pathFilter {
+		return f(d, match)
+	}
+	// Filter needs whole value before selecting it.
+	raw, err := d.rawStable()
+	if err != nil {
+		return err
+	}
+	ok, err := sel.filter.match(d.rawDecoder(raw))
+	if err != nil {
+		return errors.Wrap(err, "filter")
+	}
+	return f(d.rawDecoder(raw), ok)
+}
+
+// match reports whether value of d matches filter.
+func (e *pathFilterExpr) match(d *Decoder) (bool, error) {
+	if err := d.Pointer(e.ptr); err != nil {
+		if errors.Is(err, ErrPointerNotFound) {
+			// Nothing is not equal to anything.
+			return e.op == "!=", nil
+		}
+		return false, err
+	}
+	if e.op == "" {
+		return true, nil
+	}
+	typ := d.Next()
+	if typ != e.typ {
+		return e.op == "!=", d.Skip()
+	}
+	var cmp int
+	switch typ {
+	case Number:
+		v, err := d.Float64()
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case v < e.num:
+			cmp = -1
+		case v > e.num:
+			cmp = 1
+		}
+	case String:
+		v, err := d.StrBytes()
+		if err != nil {
+			return false, err
+		}
+		cmp = strings.Compare(string(v), e.str)
+	case Bool:
+		v, err := d.Bool()
+		if err != nil {
+			return false, err
+		}
+		if v != e.bool {
+			// Booleans are not ordered.
+			return e.op == "!=", nil
+		}
+	case Null:
+		if err := d.Null(); err != nil {
+			return false, err
+		}
+	default:
+		// Literal is always scalar.
+		return e.op == "!=", d.Skip()
+	}
+	switch e.op {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	}
+	if typ != Number && typ != String {
+		return false, nil
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default: // ">="
+		return cmp >= 0, nil
+	}
+}
+
+type pathParser struct {
+	s string
+	i int
+}
+
+func (p *pathParser) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("at %d: "+format, append([]interface{}{p.i}, args...)...)
+}
+
+func (p *pathParser) eof() bool { return p.i >= len(p.s) }
+
+func (p *pathParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *pathParser) skipSpace() {
+	for !p.eof() && spaceSet[p.s[p.i]] != 0 {
+		p.i++
+	}
+}
+
+func (p *pathParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("%q expected", c)
+	}
+	p.i++
+	return nil
+}
+
+func (p *pathParser) parse() ([]pathSegment, error) {
+	if err := p.expect('$'); err != nil {
+		return nil, err
+	}
+	var segs []pathSegment
+	for !p.eof() {
+		var seg pathSegment
+		switch p.peek() {
+		case '.':
+			p.i++
+			if p.peek() == '.' {
+				p.i++
+				seg.descendant = true
+				if p.peek() == '[' {
+					sel, err := p.bracket()
+					if err != nil {
+						return nil, err
+					}
+					seg.sel = sel
+					break
+				}
+			}
+			if p.peek() == '*' {
+				p.i++
+				seg.sel = pathSelector{kind: pathWildcard}
+				break
+			}
+			name := p.name()
+			if name == "" {
+				return nil, p.errorf("name expected")
+			}
+			seg.sel = pathSelector{kind: pathName, name: name}
+		case '[':
+			sel, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+			seg.sel = sel
+		default:
+			return nil, p.errorf("unexpected %q", p.peek())
+		}
+		segs = append(segs, seg)
+	}
+	return segs, nil
+}
+
+// name parses member name shorthand.
+func (p *pathParser) name() string {
+	start := p.i
+	for !p.eof() {
+		c := p.s[p.i]
+		if identSet[c] == 0 || c == '$' || identSet[c] == 2 && p.i == start {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// bracket parses bracketed selector.
+func (p *pathParser) bracket() (sel pathSelector, _ error) {
+	if err := p.expect('['); err != nil {
+		return sel, err
+	}
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '*':
+		p.i++
+		sel = pathSelector{kind: pathWildcard}
+	case c == '\'' || c == '"':
+		name, err := p.str()
+		if err != nil {
+			return sel, err
+		}
+		sel = pathSelector{kind: pathName, name: name}
+	case c == '?':
+		p.i++
+		filter, err := p.filter()
+		if err != nil {
+			return sel, err
+		}
+		sel = pathSelector{kind: pathFilter, filter: filter}
+	default:
+		s, err := p.slice()
+		if err != nil {
+			return sel, err
+		}
+		sel = s
+	}
+	p.skipSpace()
+	return sel, p.expect(']')
+}
+
+// slice parses index or slice.
+func (p *pathParser) slice() (pathSelector, error) {
+	var (
+		bounds  [3]int
+		set     [3]bool
+		colons  int
+		started = p.i
+	)
+	for {
+		p.skipSpace()
+		if c := p.peek(); c == '-' || c >= '0' && c <= '9' {
+			v, err := p.int()
+			if err != nil {
+				return pathSelector{}, err
+			}
+			bounds[colons], set[colons] = v, true
+			p.skipSpace()
+		}
+		if p.peek() != ':' || colons == 2 {
+			break
+		}
+		p.i++
+		colons++
+	}
+	if colons == 0 {
+		if !set[0] {
+			p.i = started
+			return pathSelector{}, p.errorf("selector expected")
+		}
+		return pathSelector{kind: pathIndex, start: bounds[0]}, nil
+	}
+	sel := pathSelector{kind: pathSlice, start: bounds[0], end: -1, step: 1}
+	if set[1] {
+		sel.end = bounds[1]
+	}
+	if set[2] {
+		if bounds[2] <= 0 {
+			return sel, p.errorf("slice step must be positive")
+		}
+		sel.step = bounds[2]
+	}
+	return sel, nil
+}
+
+func (p *pathParser) int() (int, error) {
+	start := p.i
+	if p.peek() == '-' {
+		p.i++
+	}
+	for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+		p.i++
+	}
+	v, err := strconv.Atoi(p.s[start:p.i])
+	if err != nil {
+		p.i = start
+		return 0, p.errorf("invalid integer")
+	}
+	if v < 0 {
+		p.i = start
+		return 0, p.errorf("negative index is not supported")
+	}
+	return v, nil
+}
+
+// str parses quoted string.
+func (p *pathParser) str() (string, error) {
+	quote := p.peek()
+	p.i++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.s[p.i]
+		p.i++
+		switch c {
+		case quote:
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", p.errorf("unterminated string")
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// filter parses filter expression after "?".
+func (p *pathParser) filter() (*pathFilterExpr, error) {
+	p.skipSpace()
+	paren := p.peek() == '('
+	if paren {
+		p.i++
+		p.skipSpace()
+	}
+	if err := p.expect('@'); err != nil {
+		return nil, err
+	}
+	var ptr strings.Builder
+	for {
+		var ref string
+		switch p.peek() {
+		case '.':
+			p.i++
+			ref = p.name()
+			if ref == "" {
+				return nil, p.errorf("name expected")
+			}
+		case '[':
+			p.i++
+			p.skipSpace()
+			if c := p.peek(); c == '\'' || c == '"' {
+				s, err := p.str()
+				if err != nil {
+					return nil, err
+				}
+				ref = s
+			} else {
+				v, err := p.int()
+				if err != nil {
+					return nil, err
+				}
+				ref = strconv.Itoa(v)
+			}
+			p.skipSpace()
+			if err := p.expect(']'); err != nil {
+				return nil, err
+			}
+		}
+		if ref == "" {
+			break
+		}
+		ptr.WriteByte('/')
+		ptr.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(ref))
+	}
+	e := &pathFilterExpr{ptr: ptr.String()}
+
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.s[p.i:], op) {
+			e.op = op
+			p.i += len(op)
+			break
+		}
+	}
+	if e.op != "" {
+		p.skipSpace()
+		if err := p.literal(e); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+	}
+	if paren {
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// literal parses scalar literal into e.
+func (p *pathParser) literal(e *pathFilterExpr) error {
+	if c := p.peek(); c == '\'' || c == '"' {
+		s, err := p.str()
+		if err != nil {
+			return err
+		}
+		e.typ, e.str = String, s
+		return nil
+	}
+	start := p.i
+	for !p.eof() && num5Set[p.s[p.i]] != 0 {
+		p.i++
+	}
+	lit := Raw(p.s[start:p.i])
+	if err := DecodeBytes(lit).Validate(); err == nil {
+		var err error
+		switch e.typ = lit.Type(); e.typ {
+		case Number:
+			e.num, err = DecodeBytes(lit).Float64()
+		case Bool:
+			e.bool, err = DecodeBytes(lit).Bool()
+		case Null:
+		default:
+			err = errors.New("not scalar")
+		}
+		if err == nil {
+			return nil
+		}
+	}
+	p.i = start
+	return p.errorf("literal expected")
+}
@@ -0,0 +1,165 @@
+package jx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath(t *testing.T) {
+	const input = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord", "isbn": "0-395", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 399}
+	},
+	"a.b": [1, 2, 3, 4, 5, 6]
+}`
+	for _, tt := range []struct {
+		Query  string
+		Output []string
+	}{
+		{`$.store.bicycle.color`, []string{`"red"`}},
+		{`$['store']["bicycle"]['price']`, []string{`399`}},
+		{`$.store.book[*].author`, []string{`"Nigel Rees"`, `"Evelyn Waugh"`, `"Herman Melville"`, `"J. R. R. Tolkien"`}},
+		{`$..author`, []string{`"Nigel Rees"`, `"Evelyn Waugh"`, `"Herman Melville"`, `"J. R. R. Tolkien"`}},
+		{`$.store.bicycle.*`, []string{`"red"`, `399`}},
+		{`$..price`, []string{`8.95`, `12.99`, `8.99`, `22.99`, `399`}},
+		{`$..book[2].title`, []string{`"Moby Dick"`}},
+		{`$..book[0:2].title`, []string{`"Sayings"`, `"Sword"`}},
+		{`$..book[1:].title`, []string{`"Sword"`, `"Moby Dick"`, `"The Lord"`}},
+		{`$['a.b'][::2]`, []string{`1`, `3`, `5`}},
+		{`$['a.b'][1:5:3]`, []string{`2`, `5`}},
+		{`$['a.b'][ 9 ]`, nil},
+		{`$..book[?(@.isbn)].title`, []string{`"Moby Dick"`, `"The Lord"`}},
+		{`$..book[?@.price < 10].title`, []string{`"Sayings"`, `"Moby Dick"`}},
+		{`$..book[?(@.price >= 12.99)].title`, []string{`"Sword"`, `"The Lord"`}},
+		{`$..book[?(@.category == 'reference')].author`, []string{`"Nigel Rees"`}},
+		{`$..book[?(@.category != "fiction")].author`, []string{`"Nigel Rees"`}},
+		{`$..book[?(@.isbn != "0-553")].title`, []string{`"Sayings"`, `"Sword"`, `"The Lord"`}},
+		{`$['a.b'][?(@ > 4)]`, []string{`5`, `6`}},
+		{`$..[?(@.color == 'red')].price`, []string{`399`}},
+		{`$..book[?(@.price == true)]`, nil},
+		{`$.store.book[0].*`, []string{`"reference"`, `"Nigel Rees"`, `"Sayings"`, `8.95`}},
+		{`$.missing`, nil},
+		{`$.store.bicycle.color.missing`, nil},
+	} {
+		tt := tt
+		p, err := ParseJSONPath(tt.Query)
+		require.NoError(t, err, tt.Query)
+		require.Equal(t, tt.Query, p.String())
+		t.Run(tt.Query, testBufferReader(input, func(t *testing.T, d *Decoder) {
+			var got []string
+			require.NoError(t, p.Query(d, func(raw Raw) error {
+				got = append(got, raw.String())
+				return nil
+			}))
+			require.Equal(t, tt.Output, got)
+			// Whole value is consumed.
+			require.Equal(t, Invalid, d.Next())
+		}))
+	}
+	t.Run("Root", func(t *testing.T) {
+		p, err := ParseJSONPath(`$`)
+		require.NoError(t, err)
+		var got []string
+		require.NoError(t, p.Query(DecodeStr(`[1, {"a": 2}]`), func(raw Raw) error {
+			got = append(got, raw.String())
+			return nil
+		}))
+		require.Equal(t, []string{`[1, {"a": 2}]`}, got)
+	})
+	t.Run("Descendant", func(t *testing.T) {
+		p, err := ParseJSONPath(`$..a`)
+		require.NoError(t, err)
+		var got []string
+		require.NoError(t, p.Query(DecodeStr(`{"a": {"a": 1, "b": [{"a": 2}]}}`), func(raw Raw) error {
+			got = append(got, raw.String())
+			return nil
+		}))
+		require.Equal(t, []string{`{"a": 1, "b": [{"a": 2}]}`, `1`, `2`}, got)
+	})
+	t.Run("DescendantJSON5", testBufferReader(`{a: {a: 1, b: [{a: 0x2,},],},}`, func(t *testing.T, d *Decoder) {
+		d.SetSyntax(SyntaxJSON5)
+		p, err := ParseJSONPath(`$..a`)
+		require.NoError(t, err)
+		var got []string
+		require.NoError(t, p.Query(d, func(raw Raw) error {
+			got = append(got, raw.String())
+			return nil
+		}))
+		require.Equal(t, []string{`{"a":1,"b":[{"a":2}]}`, `1`, `2`}, got)
+	}))
+	t.Run("Invalid", func(t *testing.T) {
+		for _, expr := range []string{
+			``,
+			`store`,
+			`$.`,
+			`$..`,
+			`$[`,
+			`$[]`,
+			`$[-1]`,
+			`$[1:2:0]`,
+			`$['a`,
+			`$[?(@.a == )]`,
+			`$[?(@.a == 'b']`,
+			`$[?(a)]`,
+			`$[?(@.a == [1])]`,
+			`$.a b`,
+		} {
+			_, err := ParseJSONPath(expr)
+			require.Error(t, err, expr)
+		}
+	})
+	t.Run("Depth", func(t *testing.T) {
+		p, err := ParseJSONPath(`$..a`)
+		require.NoError(t, err)
+		d := DecodeStr(`[[[[{"a": 1}]]]]`)
+		d.SetMaxDepth(3)
+		require.ErrorIs(t, p.Query(d, func(raw Raw) error { return nil }), ErrMaxDepth)
+	})
+}
+
+func BenchmarkJSONPath_Descendant(b *testing.B) {
+	const depth = 500
+	input := []byte(strings.Repeat("[1,", depth) + "1" + strings.Repeat("]", depth))
+	p, err := ParseJSONPath(`$..*`)
+	require.NoError(b, err)
+
+	for _, bb := range []struct {
+		Name string
+		New  func() *Decoder
+	}{
+		{"Buffer", func() *Decoder { return DecodeBytes(input) }},
+		{"Reader", func() *Decoder { return Decode(bytes.NewReader(input), 0) }},
+		{"JSON5", func() *Decoder {
+			d := DecodeBytes(input)
+			d.SetSyntax(SyntaxJSON5)
+			return d
+		}},
+	} {
+		bb := bb
+		b.Run(bb.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				n := 0
+				if err := p.Query(bb.New(), func(raw Raw) error {
+					n++
+					return nil
+				}); err != nil {
+					b.Fatal(err)
+				}
+				if n != 2*depth {
+					b.Fatal(n)
+				}
+			}
+		})
+	}
+}
@@ -28,24 +28,24 @@ func (d *Decoder) num(v Num, forceAppend bool) (Num, error) {
 			return Num{}, errors.Wrap(err, "str")
 		}
 
-		// Validate number.
-		{
-			d := Decoder{}
-			d.ResetBytes(str.buf)
+		// Validate number, unless it is accepted NaN or infinity.
+		if _, ok := parseNonFinite(str.buf); !ok || !d.opts.nonFiniteStrings {
+			nd := Decoder{}
+			nd.ResetBytes(str.buf)
 
-			c, err := d.next()
+			c, err := nd.next()
 			if err != nil {
 				return Num{}, err
 			}
 			switch c {
 			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '-':
-				d.unread()
+				nd.unread()
 
-				if err := d.skipNumber(); err != nil {
+				if err := nd.skipNumber(); err != nil {
 					return Num{}, errors.Wrap(err, "skip number")
 				}
 			default:
-				return nil, badToken(c, offset)
+				return nil, d.badToken(c, offset)
 			}
 		}
 
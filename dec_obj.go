@@ -8,6 +8,18 @@ import (
 //
 // The key value is valid only until f is not returned.
 func (d *Decoder) ObjBytes(f func(d *Decoder, key []byte) error) error {
+	if d.opts.trackPath && f != nil {
+		return rootPathErr(d.objBytes(func(d *Decoder, key []byte) error {
+			if err := f(d, key); err != nil {
+				return pathErr(err, pathElem{key: string(key), index: -1})
+			}
+			return nil
+		}))
+	}
+	return d.objBytes(f)
+}
+
+func (d *Decoder) objBytes(f func(d *Decoder, key []byte) error) error {
 	if err := d.consume('{'); err != nil {
 		return errors.Wrap(err, `"{" expected`)
 	}
@@ -17,6 +29,7 @@ func (d *Decoder) ObjBytes(f func(d *Decoder, key []byte) error) error {
 	if err := d.incDepth(); err != nil {
 		return err
 	}
+	d.objStart()
 	c, err := d.more()
 	if err != nil {
 		return errors.Wrap(err, `'"' or "}" expected`)
@@ -32,7 +45,7 @@ func (d *Decoder) ObjBytes(f func(d *Decoder, key []byte) error) error {
 	// See https://github.com/go-faster/jx/pull/62.
 	isBuffer := d.reader == nil
 
-	k, err := d.str(value{raw: isBuffer})
+	k, err := d.objKey(value{raw: isBuffer})
 	if err != nil {
 		return errors.Wrap(err, "field name")
 	}
@@ -53,7 +66,14 @@ func (d *Decoder) ObjBytes(f func(d *Decoder, key []byte) error) error {
 		return errors.Wrap(err, `"," or "}" expected`)
 	}
 	for c == ',' {
-		k, err := d.str(value{raw: isBuffer})
+		end, err := d.trailingComma('}')
+		if err != nil {
+			return err
+		}
+		if end {
+			return d.decDepth()
+		}
+		k, err := d.objKey(value{raw: isBuffer})
 		if err != nil {
 			return errors.Wrap(err, "field name")
 		}
@@ -73,7 +93,7 @@ func (d *Decoder) ObjBytes(f func(d *Decoder, key []byte) error) error {
 		}
 	}
 	if c != '}' {
-		err := badToken(c, d.offset()-1)
+		err := d.badToken(c, d.offset()-1)
 		return errors.Wrap(err, `"}" expected`)
 	}
 	return d.decDepth()
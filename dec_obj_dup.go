@@ -0,0 +1,94 @@
+package jx
+
+import (
+	"fmt"
+)
+
+// DuplicateKeyError is returned when object has duplicate key and
+// duplicate keys are rejected.
+//
+// See Decoder.SetRejectDuplicateKeys.
+type DuplicateKeyError struct {
+	Key    string // decoded key
+	Offset int    // offset of duplicate key in input
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key %q at %d", e.Key, e.Offset)
+}
+
+// SetRejectDuplicateKeys sets whether Obj, ObjBytes, ObjIter, Skip and
+// Validate should fail with *DuplicateKeyError on duplicate keys of the
+// same object.
+//
+// Keys are compared after unescaping.
+func (d *Decoder) SetRejectDuplicateKeys(reject bool) {
+	d.opts.rejectDupKeys = reject
+}
+
+// objStart resets duplicate key tracking state for object at current depth.
+//
+// Must be called after depth increment.
+func (d *Decoder) objStart() {
+	if !d.opts.rejectDupKeys {
+		return
+	}
+	keys := d.objKeys()
+	for k := range keys {
+		delete(keys, k)
+	}
+}
+
+// objKeys returns set of keys for object at current depth.
+func (d *Decoder) objKeys() map[string]struct{} {
+	for len(d.keys) <= d.depth {
+		d.keys = append(d.keys, nil)
+	}
+	keys := d.keys[d.depth]
+	if keys == nil {
+		keys = map[string]struct{}{}
+		d.keys[d.depth] = keys
+	}
+	return keys
+}
+
+// objKey reads object key, rejecting duplicates if needed.
+func (d *Decoder) objKey(v value) (value, error) {
+	if !d.opts.rejectDupKeys {
+		return d.key(v)
+	}
+	if err := d.skipSpace(); err != nil {
+		return value{}, err
+	}
+	offset := d.offset()
+	k, err := d.key(v)
+	if err != nil {
+		return k, err
+	}
+	return k, d.checkKey(k.buf, offset)
+}
+
+// skipObjKey skips object key, rejecting duplicates if needed.
+//
+// Assumes first quote was consumed.
+func (d *Decoder) skipObjKey() error {
+	if !d.opts.rejectDupKeys {
+		return d.skipStr()
+	}
+	offset := d.offset() - 1
+	k, err := d.strBody(value{buf: d.scratch[:0]})
+	if err != nil {
+		return err
+	}
+	d.scratch = k.buf[:0]
+	return d.checkKey(k.buf, offset)
+}
+
+func (d *Decoder) checkKey(key []byte, offset int) error {
+	keys := d.objKeys()
+	if _, ok := keys[string(key)]; ok {
+		return &DuplicateKeyError{Key: string(key), Offset: offset}
+	}
+	keys[string(key)] = struct{}{}
+	return nil
+}
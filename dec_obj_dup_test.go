@@ -0,0 +1,87 @@
+package jx
+
+import (
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_SetRejectDuplicateKeys(t *testing.T) {
+	iterObj := func(d *Decoder) error {
+		iter, err := d.ObjIter()
+		if err != nil {
+			return err
+		}
+		for iter.Next() {
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	}
+	for _, tt := range []struct {
+		Name   string
+		Decode func(d *Decoder) error
+	}{
+		{"Validate", (*Decoder).Validate},
+		{"Skip", (*Decoder).Skip},
+		{"Obj", crawlValue},
+		{"ObjBytes", func(d *Decoder) error {
+			return d.ObjBytes(func(d *Decoder, key []byte) error {
+				return crawlValue(d)
+			})
+		}},
+		{"ObjIter", iterObj},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			for _, input := range []string{
+				`{}`,
+				`{"a":1,"b":2}`,
+				`{"a":{"a":1},"b":{"a":{"a":2}}}`,
+				`{"a":[{"a":1},{"a":2}],"b":{}}`,
+				`{"a":1,"A":2,"a ":3}`,
+			} {
+				t.Run("Unique", testBufferReader(input, func(t *testing.T, d *Decoder) {
+					d.SetRejectDuplicateKeys(true)
+					require.NoError(t, tt.Decode(d))
+				}))
+			}
+			for _, c := range []struct {
+				Input  string
+				Key    string
+				Offset int
+			}{
+				{`{"a":1,"a":2}`, "a", 7},
+				{`{"a":1,"\u0061":2}`, "a", 7},
+				{`{"a":1, "b":2,  "a":3}`, "a", 16},
+				{`{"x":{"a":1,"b":{"a":1},"a":2}}`, "a", 24},
+				{`{"x":[{"a":1},{"a":1,"a":2}]}`, "a", 21},
+				{`{"":1,"":2}`, "", 6},
+			} {
+				t.Run("Duplicate", testBufferReader(c.Input, func(t *testing.T, d *Decoder) {
+					d.SetRejectDuplicateKeys(true)
+					err := tt.Decode(d)
+
+					var de *DuplicateKeyError
+					require.True(t, errors.As(err, &de), "%+v", err)
+					require.Equal(t, c.Key, de.Key)
+					require.Equal(t, c.Offset, de.Offset)
+				}))
+				t.Run("Allowed", testBufferReader(c.Input, func(t *testing.T, d *Decoder) {
+					require.NoError(t, tt.Decode(d))
+				}))
+			}
+		})
+	}
+	t.Run("Reuse", func(t *testing.T) {
+		d := GetDecoder()
+		defer PutDecoder(d)
+		d.SetRejectDuplicateKeys(true)
+		for i := 0; i < 3; i++ {
+			d.ResetBytes([]byte(`{"a":1,"b":2}`))
+			require.NoError(t, d.Validate())
+		}
+	})
+}
@@ -20,6 +20,7 @@ func (d *Decoder) ObjIter() (ObjIter, error) {
 	if err := d.incDepth(); err != nil {
 		return ObjIter{}, err
 	}
+	d.objStart()
 	if _, err := d.more(); err != nil {
 		return ObjIter{}, err
 	}
@@ -53,15 +54,25 @@ func (i *ObjIter) Next() bool {
 	}
 	if i.comma {
 		if c != ',' {
-			err := badToken(c, dec.offset()-1)
+			err := dec.badToken(c, dec.offset()-1)
 			i.err = errors.Wrap(err, `"," expected`)
 			return false
 		}
+		end, err := dec.trailingComma('}')
+		if err != nil {
+			i.err = err
+			return false
+		}
+		if end {
+			i.closed = true
+			i.err = dec.decDepth()
+			return false
+		}
 	} else {
 		dec.unread()
 	}
 
-	k, err := dec.str(value{raw: i.isBuffer})
+	k, err := dec.objKey(value{raw: i.isBuffer})
 	if err != nil {
 		i.err = errors.Wrap(err, "field name")
 		return false
@@ -72,7 +83,7 @@ func (i *ObjIter) Next() bool {
 	}
 	// Skip whitespace.
 	if _, err = dec.more(); err != nil {
-		err := badToken(c, dec.offset()-1)
+		err := dec.badToken(c, dec.offset()-1)
 		i.err = errors.Wrap(err, `"," or "}" expected`)
 		return false
 	}
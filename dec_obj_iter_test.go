@@ -53,8 +53,8 @@ func TestDecoder_ObjIter(t *testing.T) {
 	t.Run("Depth", func(t *testing.T) {
 		d := DecodeStr(`{`)
 		// Emulate depth
-		d.depth = maxDepth
-		require.ErrorIs(t, testIter(d), errMaxDepth)
+		d.depth = defaultMaxDepth
+		require.ErrorIs(t, testIter(d), ErrMaxDepth)
 	})
 	t.Run("Empty", func(t *testing.T) {
 		d := DecodeStr(``)
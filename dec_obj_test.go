@@ -36,13 +36,13 @@ func TestDecoder_ObjBytes(t *testing.T) {
 	})
 	t.Run("Depth", func(t *testing.T) {
 		var input []byte
-		for i := 0; i <= maxDepth; i++ {
+		for i := 0; i <= defaultMaxDepth; i++ {
 			input = append(input, `{"1":`...)
 		}
 		d := DecodeBytes(input)
 		require.ErrorIs(t, d.ObjBytes(func(d *Decoder, key []byte) error {
 			return crawlValue(d)
-		}), errMaxDepth)
+		}), ErrMaxDepth)
 	})
 	t.Run("Invalid", func(t *testing.T) {
 		for _, s := range testObjs {
@@ -0,0 +1,242 @@
+package jx
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// ndjsonChunkSize is approximate size of input passed to single worker.
+var ndjsonChunkSize = 1 << 20
+
+// NDJSONRecord is result of decoding single record of newline-delimited
+// json.
+type NDJSONRecord[T any] struct {
+	Index  int   // zero-based index of record, blank lines are not counted
+	Offset int   // input offset of record json text
+	Value  T     // value returned by decode function
+	Err    error // decoding error
+}
+
+// ParallelNDJSON decodes records of newline-delimited json data in
+// parallel, calling f for every record in original order.
+//
+// Every record is decoded by decode function on one of workers goroutines,
+// which defaults to GOMAXPROCS if not positive. Decoder passed to decode
+// function references data, so values like Raw can be returned without
+// copying. Decode function must consume the whole record, otherwise
+// record error matches ErrTrailingData.
+//
+// Record errors are passed to f. Decoding stops on first error returned by
+// f, which is returned.
+func ParallelNDJSON[T any](
+	data []byte,
+	workers int,
+	decode func(d *Decoder) (T, error),
+	f func(r NDJSONRecord[T]) error,
+) error {
+	return parallelNDJSON(func(free <-chan []byte) (ndjsonChunk[T], error) {
+		if len(data) == 0 {
+			return ndjsonChunk[T]{}, io.EOF
+		}
+		n := len(data)
+		if n > ndjsonChunkSize {
+			n = ndjsonChunkSize
+			if i := bytes.IndexByte(data[n:], '\n'); i >= 0 {
+				n += i + 1
+			} else {
+				n = len(data)
+			}
+		}
+		c := ndjsonChunk[T]{data: data[:n]}
+		data = data[n:]
+		return c, nil
+	}, workers, decode, f)
+}
+
+// ParallelNDJSONReader is ParallelNDJSON for input from reader.
+//
+// Input is read by chunks, so Decoder passed to decode function references
+// buffer which is reused after call to f for the record.
+func ParallelNDJSONReader[T any](
+	r io.Reader,
+	workers int,
+	decode func(d *Decoder) (T, error),
+	f func(r NDJSONRecord[T]) error,
+) error {
+	var (
+		carry   []byte // incomplete record from previous chunk
+		readErr error  // deferred until complete records are decoded
+	)
+	return parallelNDJSON(func(free <-chan []byte) (ndjsonChunk[T], error) {
+		if readErr != nil {
+			return ndjsonChunk[T]{}, readErr
+		}
+		var buf []byte
+		select {
+		case buf = <-free:
+		default:
+		}
+		size := ndjsonChunkSize
+		for {
+			if n := 2 * len(carry); n > size {
+				// Record does not fit into chunk.
+				size = n
+			}
+			if cap(buf) < size {
+				buf = make([]byte, 0, size)
+			}
+			buf = append(buf[:0], carry...)
+			for len(buf) < cap(buf) && readErr == nil {
+				n, err := r.Read(buf[len(buf):cap(buf)])
+				buf = buf[:len(buf)+n]
+				readErr = err
+			}
+			n := bytes.LastIndexByte(buf, '\n') + 1
+			if readErr == io.EOF {
+				// Last record may be not terminated by newline.
+				n = len(buf)
+			}
+			carry = append(carry[:0], buf[n:]...)
+			if n == 0 {
+				if readErr != nil {
+					return ndjsonChunk[T]{}, readErr
+				}
+				// No complete record in buffer, read more.
+				continue
+			}
+			return ndjsonChunk[T]{data: buf[:n], buf: buf}, nil
+		}
+	}, workers, decode, f)
+}
+
+// ndjsonChunk is part of input with complete records.
+type ndjsonChunk[T any] struct {
+	data    []byte
+	buf     []byte // buffer to reuse, if any
+	offset  int
+	records []NDJSONRecord[T]
+	done    chan struct{}
+}
+
+// decode decodes records of chunk, with indexes relative to chunk.
+func (c *ndjsonChunk[T]) decode(decode func(d *Decoder) (T, error)) {
+	d := GetDecoder()
+	defer PutDecoder(d)
+
+	data := c.data
+	for offset := 0; offset < len(data); {
+		line := data[offset:]
+		if i := bytes.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+		}
+		start := offset
+		offset += len(line) + 1
+
+		i := scanSpace(line)
+		if i == len(line) {
+			// Blank line.
+			continue
+		}
+		d.ResetBytes(line[i:])
+		v, err := decode(d)
+		if err == nil {
+			err = d.end()
+		}
+		c.records = append(c.records, NDJSONRecord[T]{
+			Index:  len(c.records),
+			Offset: c.offset + start + i,
+			Value:  v,
+			Err:    err,
+		})
+	}
+}
+
+func parallelNDJSON[T any](
+	next func(free <-chan []byte) (ndjsonChunk[T], error),
+	workers int,
+	decode func(d *Decoder) (T, error),
+	f func(r NDJSONRecord[T]) error,
+) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var (
+		work    = make(chan *ndjsonChunk[T], workers)
+		ordered = make(chan *ndjsonChunk[T], 2*workers)
+		free    = make(chan []byte, 2*workers)
+		stop    = make(chan struct{})
+		wg      sync.WaitGroup
+		readErr error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range work {
+				c.decode(decode)
+				close(c.done)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(work)
+		defer close(ordered)
+		offset := 0
+		for {
+			c, err := next(free)
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+			c.offset = offset
+			c.done = make(chan struct{})
+			offset += len(c.data)
+			select {
+			case ordered <- &c:
+			case <-stop:
+				return
+			}
+			select {
+			case work <- &c:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var (
+		index int
+		err   error
+	)
+	for c := range ordered {
+		<-c.done
+		for _, r := range c.records {
+			r.Index += index
+			if err = f(r); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			close(stop)
+			break
+		}
+		index += len(c.records)
+		if c.buf != nil {
+			select {
+			case free <- c.buf:
+			default:
+			}
+		}
+	}
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	return readErr
+}
@@ -0,0 +1,184 @@
+package jx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func setNDJSONChunkSize(t *testing.T, n int) {
+	t.Helper()
+	prev := ndjsonChunkSize
+	ndjsonChunkSize = n
+	t.Cleanup(func() { ndjsonChunkSize = prev })
+}
+
+func TestParallelNDJSON(t *testing.T) {
+	setNDJSONChunkSize(t, 64)
+
+	const n = 1000
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		switch i % 7 {
+		case 0:
+			b.WriteString("\n")
+		case 1:
+			b.WriteString("  \t\r\n")
+		}
+		fmt.Fprintf(&b, " {\"id\": %d}\r\n", i)
+	}
+	data := []byte(b.String())
+	decode := func(d *Decoder) (int, error) {
+		var id int
+		err := d.ObjBytes(func(d *Decoder, key []byte) error {
+			v, err := d.Int()
+			id = v
+			return err
+		})
+		return id, err
+	}
+	check := func(t *testing.T, run func(f func(r NDJSONRecord[int]) error) error) {
+		t.Helper()
+		i := 0
+		require.NoError(t, run(func(r NDJSONRecord[int]) error {
+			require.NoError(t, r.Err)
+			require.Equal(t, i, r.Index)
+			require.Equal(t, i, r.Value)
+			require.True(t, bytes.HasPrefix(data[r.Offset:], []byte(`{"id"`)))
+			i++
+			return nil
+		}))
+		require.Equal(t, n, i)
+	}
+	for _, workers := range []int{0, 1, 4} {
+		workers := workers
+		t.Run(fmt.Sprintf("Workers%d", workers), func(t *testing.T) {
+			t.Run("Bytes", func(t *testing.T) {
+				check(t, func(f func(r NDJSONRecord[int]) error) error {
+					return ParallelNDJSON(data, workers, decode, f)
+				})
+			})
+			t.Run("Reader", func(t *testing.T) {
+				check(t, func(f func(r NDJSONRecord[int]) error) error {
+					return ParallelNDJSONReader(bytes.NewReader(data), workers, decode, f)
+				})
+			})
+			t.Run("OneByteReader", func(t *testing.T) {
+				check(t, func(f func(r NDJSONRecord[int]) error) error {
+					return ParallelNDJSONReader(iotest.OneByteReader(bytes.NewReader(data)), workers, decode, f)
+				})
+			})
+		})
+	}
+}
+
+func TestParallelNDJSON_Records(t *testing.T) {
+	setNDJSONChunkSize(t, 16)
+
+	long := `"` + strings.Repeat("a", 100) + `"`
+	data := []byte("1\n[2,]\n" + long + "\n\n3 4\n{\"a\":\n5")
+	for _, tt := range []struct {
+		Name string
+		Run  func(f func(r NDJSONRecord[Raw]) error) error
+	}{
+		{"Bytes", func(f func(r NDJSONRecord[Raw]) error) error {
+			return ParallelNDJSON(data, 2, (*Decoder).Raw, f)
+		}},
+		{"Reader", func(f func(r NDJSONRecord[Raw]) error) error {
+			return ParallelNDJSONReader(bytes.NewReader(data), 2, func(d *Decoder) (Raw, error) {
+				raw, err := d.Raw()
+				// Buffer is reused.
+				return append(Raw(nil), raw...), err
+			}, f)
+		}},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			var records []NDJSONRecord[Raw]
+			require.NoError(t, tt.Run(func(r NDJSONRecord[Raw]) error {
+				records = append(records, r)
+				return nil
+			}))
+			require.Len(t, records, 6)
+			for i, r := range records {
+				require.Equal(t, i, r.Index)
+			}
+
+			require.NoError(t, records[0].Err)
+			require.Equal(t, "1", records[0].Value.String())
+			require.Error(t, records[1].Err)
+			require.Equal(t, 2, records[1].Offset)
+			require.NoError(t, records[2].Err)
+			require.Equal(t, long, records[2].Value.String())
+			require.ErrorIs(t, records[3].Err, ErrTrailingData)
+			require.Error(t, records[4].Err)
+			require.NoError(t, records[5].Err)
+			require.Equal(t, "5", records[5].Value.String())
+			require.Equal(t, len(data)-1, records[5].Offset)
+		})
+	}
+	t.Run("ZeroCopy", func(t *testing.T) {
+		require.NoError(t, ParallelNDJSON(data, 2, (*Decoder).Raw, func(r NDJSONRecord[Raw]) error {
+			if r.Err == nil {
+				require.Same(t, &data[r.Offset], &r.Value[0])
+			}
+			return nil
+		}))
+	})
+}
+
+func TestParallelNDJSON_Error(t *testing.T) {
+	setNDJSONChunkSize(t, 16)
+
+	data := []byte(strings.Repeat("1\n", 1000))
+	decode := func(d *Decoder) (int, error) { return d.Int() }
+	t.Run("Callback", func(t *testing.T) {
+		errStop := errors.New("stop")
+		for _, run := range []func(f func(r NDJSONRecord[int]) error) error{
+			func(f func(r NDJSONRecord[int]) error) error {
+				return ParallelNDJSON(data, 4, decode, f)
+			},
+			func(f func(r NDJSONRecord[int]) error) error {
+				return ParallelNDJSONReader(bytes.NewReader(data), 4, decode, f)
+			},
+		} {
+			calls := 0
+			require.ErrorIs(t, run(func(r NDJSONRecord[int]) error {
+				calls++
+				if r.Index == 10 {
+					return errStop
+				}
+				return nil
+			}), errStop)
+			require.Equal(t, 11, calls)
+		}
+	})
+	t.Run("Reader", func(t *testing.T) {
+		r := io.MultiReader(bytes.NewReader(data), errReader{})
+		calls := 0
+		err := ParallelNDJSONReader(r, 4, decode, func(r NDJSONRecord[int]) error {
+			calls++
+			return r.Err
+		})
+		require.ErrorIs(t, err, io.ErrNoProgress)
+		require.Equal(t, 1000, calls)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		for _, input := range []string{"", "\n \n"} {
+			require.NoError(t, ParallelNDJSON([]byte(input), 1, decode, func(r NDJSONRecord[int]) error {
+				t.Fatal("unexpected record")
+				return nil
+			}))
+			require.NoError(t, ParallelNDJSONReader(strings.NewReader(input), 1, decode, func(r NDJSONRecord[int]) error {
+				t.Fatal("unexpected record")
+				return nil
+			}))
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package jx
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// SetTrackPath sets whether Obj, ObjBytes and Arr should attach path
+// of failing value to returned error as *PathError.
+//
+// Path is collected only on error, so tracking does not affect successful
+// decoding.
+func (d *Decoder) SetTrackPath(enable bool) {
+	d.opts.trackPath = enable
+}
+
+type pathElem struct {
+	key   string
+	index int // -1 if element is object key
+}
+
+// PathError is decoding error with path to failing value.
+type PathError struct {
+	Err error
+	// elems of path, innermost first.
+	elems []pathElem
+}
+
+// Path returns JSONPath-like path to failing value, e.g. $.items[42].price.
+func (e *PathError) Path() string {
+	var b strings.Builder
+	b.WriteByte('$')
+	for i := len(e.elems) - 1; i >= 0; i-- {
+		elem := e.elems[i]
+		if elem.index >= 0 {
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(elem.index))
+			b.WriteByte(']')
+			continue
+		}
+		if isPathIdent(elem.key) {
+			b.WriteByte('.')
+			b.WriteString(elem.key)
+			continue
+		}
+		b.WriteString("['")
+		for _, c := range []byte(elem.key) {
+			if c == '\'' || c == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(c)
+		}
+		b.WriteString("']")
+	}
+	return b.String()
+}
+
+func isPathIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range []byte(s) {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (e *PathError) Error() string {
+	return e.Path() + ": " + e.Err.Error()
+}
+
+// Unwrap returns underlying error.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// pathErr appends elem to path of err, wrapping it into *PathError if needed.
+func pathErr(err error, elem pathElem) error {
+	if pe, ok := errors.Into[*PathError](err); ok {
+		pe.elems = append(pe.elems, elem)
+		return err
+	}
+	return &PathError{Err: err, elems: []pathElem{elem}}
+}
+
+// rootPathErr wraps err into *PathError if err has no path.
+func rootPathErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := errors.Into[*PathError](err); ok {
+		return err
+	}
+	return &PathError{Err: err}
+}
@@ -0,0 +1,91 @@
+package jx
+
+import (
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_SetTrackPath(t *testing.T) {
+	errPrice := errors.New("bad price")
+
+	decodeItems := func(d *Decoder) error {
+		return d.Obj(func(d *Decoder, key string) error {
+			if key != "items" {
+				return d.Skip()
+			}
+			return d.Arr(func(d *Decoder) error {
+				return d.ObjBytes(func(d *Decoder, key []byte) error {
+					if string(key) == "price" {
+						v, err := d.Int()
+						if err != nil {
+							return err
+						}
+						if v < 0 {
+							return errPrice
+						}
+						return nil
+					}
+					return d.Skip()
+				})
+			})
+		})
+	}
+
+	for _, tt := range []struct {
+		Name  string
+		Input string
+		Path  string
+	}{
+		{"Callback", `{"items":[{"price":1},{"id":2,"price":-1}]}`, "$.items[1].price"},
+		{"Value", `{"x":null,"items":[{"price":1},{"price":1},{"price":"1"}]}`, "$.items[2].price"},
+		{"Syntax", `{"items":[{"price":1},{"price" 1}]}`, "$.items[1]"},
+		{"Root", `{"items":[]`, "$"},
+		{"Skip", `{"items":[{"id":[1,2,}]}]}`, "$.items[0].id"},
+	} {
+		tt := tt
+		t.Run(tt.Name, testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+			d.SetTrackPath(true)
+			err := decodeItems(d)
+			require.Error(t, err)
+
+			var pe *PathError
+			require.True(t, errors.As(err, &pe), "%+v", err)
+			require.Equal(t, tt.Path, pe.Path())
+			require.Contains(t, pe.Error(), tt.Path)
+		}))
+	}
+	t.Run("Is", func(t *testing.T) {
+		d := DecodeStr(`{"items":[{"price":-1}]}`)
+		d.SetTrackPath(true)
+		require.ErrorIs(t, decodeItems(d), errPrice)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		d := DecodeStr(`{"items":[{"price":-1}]}`)
+		err := decodeItems(d)
+		require.ErrorIs(t, err, errPrice)
+
+		var pe *PathError
+		require.False(t, errors.As(err, &pe))
+	})
+}
+
+func TestPathError_Path(t *testing.T) {
+	for _, tt := range []struct {
+		Elems []pathElem
+		Path  string
+	}{
+		{nil, "$"},
+		{[]pathElem{{index: 0}}, "$[0]"},
+		{[]pathElem{{key: "b", index: -1}, {key: "a", index: -1}}, "$.a.b"},
+		{[]pathElem{{key: "_a1", index: -1}, {index: 10}}, "$[10]._a1"},
+		{[]pathElem{{key: "", index: -1}}, "$['']"},
+		{[]pathElem{{key: "1a", index: -1}}, "$['1a']"},
+		{[]pathElem{{key: `it's\`, index: -1}}, `$['it\'s\\']`},
+		{[]pathElem{{key: "a.b", index: -1}}, "$['a.b']"},
+	} {
+		e := &PathError{Err: errors.New("err"), elems: tt.Elems}
+		require.Equal(t, tt.Path, e.Path())
+	}
+}
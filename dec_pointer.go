@@ -0,0 +1,152 @@
+package jx
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// ErrPointerNotFound means that value addressed by json pointer does not
+// exist.
+var ErrPointerNotFound = errors.New("pointer: not found")
+
+// Pointer positions decoder at value addressed by RFC 6901 json pointer,
+// like "/data/items/3/id", skipping preceding values.
+//
+// Empty pointer addresses whole value. Returns ErrPointerNotFound if there is
+// no such value. If object has duplicate keys, first one is used.
+//
+// Decoder is left inside arrays and objects that contain the value, so the
+// value should be the last one read from the decoder.
+func (d *Decoder) Pointer(ptr string) error {
+	if ptr != "" && ptr[0] != '/' {
+		return errors.Errorf("invalid pointer %q: must start with %q", ptr, '/')
+	}
+	for ptr != "" {
+		ref := ptr[1:]
+		if i := strings.IndexByte(ref, '/'); i >= 0 {
+			ref, ptr = ref[:i], ref[i:]
+		} else {
+			ptr = ""
+		}
+		if err := d.pointerRef(ref); err != nil {
+			return err
+		}
+	}
+	return d.skipSpace()
+}
+
+// pointerRef positions decoder at value of current array or object
+// addressed by reference token.
+func (d *Decoder) pointerRef(ref string) error {
+	switch d.Next() {
+	case Object:
+		key, err := unescapePointer(ref)
+		if err != nil {
+			return err
+		}
+		iter, err := d.ObjIter()
+		if err != nil {
+			return err
+		}
+		for iter.Next() {
+			if string(iter.Key()) == key {
+				return nil
+			}
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		return errors.Wrapf(ErrPointerNotFound, "key %q", key)
+	case Array:
+		idx, ok := pointerIndex(ref)
+		if !ok {
+			return errors.Wrapf(ErrPointerNotFound, "index %q", ref)
+		}
+		iter, err := d.ArrIter()
+		if err != nil {
+			return err
+		}
+		for i := 0; iter.Next(); i++ {
+			if i == idx {
+				return nil
+			}
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		return errors.Wrapf(ErrPointerNotFound, "index %d", idx)
+	case Invalid:
+		// Report actual error.
+		_, err := d.more()
+		if err == nil {
+			d.unread()
+			err = d.badToken(d.buf[d.head], d.offset())
+		}
+		return err
+	default:
+		return errors.Wrapf(ErrPointerNotFound, "reference %q in %s", ref, d.Next())
+	}
+}
+
+// unescapePointer unescapes json pointer reference token.
+func unescapePointer(ref string) (string, error) {
+	i := strings.IndexByte(ref, '~')
+	if i < 0 {
+		return ref, nil
+	}
+	var b strings.Builder
+	b.Grow(len(ref))
+	for ; i >= 0; i = strings.IndexByte(ref, '~') {
+		b.WriteString(ref[:i])
+		if i+1 >= len(ref) {
+			return "", errors.Errorf("invalid pointer escape %q", ref[i:])
+		}
+		switch ref[i+1] {
+		case '0':
+			b.WriteByte('~')
+		case '1':
+			b.WriteByte('/')
+		default:
+			return "", errors.Errorf("invalid pointer escape %q", ref[i:i+2])
+		}
+		ref = ref[i+2:]
+	}
+	b.WriteString(ref)
+	return b.String(), nil
+}
+
+// pointerIndex parses json pointer array index.
+//
+// Returns false for invalid index and for "-", which addresses nonexistent
+// element after the last one.
+func pointerIndex(ref string) (int, bool) {
+	if ref == "" || len(ref) > 1 && ref[0] == '0' || len(ref) > 9 {
+		return 0, false
+	}
+	n := 0
+	for _, c := range []byte(ref) {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// Pointer returns value addressed by RFC 6901 json pointer.
+//
+// See Decoder.Pointer.
+func (r Raw) Pointer(ptr string) (Raw, error) {
+	d := Decoder{buf: r, tail: len(r)}
+	if err := d.Pointer(ptr); err != nil {
+		return nil, err
+	}
+	return d.Raw()
+}
@@ -0,0 +1,178 @@
+package jx
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// PointerSet is compiled set of RFC 6901 json pointers for extraction of
+// multiple values in single pass.
+type PointerSet struct {
+	ptrs []string
+	root *pointerNode
+}
+
+// pointerNode is node of reference token trie.
+type pointerNode struct {
+	match   []int // indexes of pointers that end at node
+	keys    map[string]*pointerNode
+	indexes map[int]*pointerNode // same nodes for tokens that are array indexes
+}
+
+func (n *pointerNode) child(ref string) *pointerNode {
+	if c, ok := n.keys[ref]; ok {
+		return c
+	}
+	c := &pointerNode{}
+	if n.keys == nil {
+		n.keys = map[string]*pointerNode{}
+	}
+	n.keys[ref] = c
+	return c
+}
+
+// NewPointerSet compiles set of json pointers.
+func NewPointerSet(ptrs ...string) (*PointerSet, error) {
+	s := &PointerSet{
+		ptrs: append([]string(nil), ptrs...),
+		root: &pointerNode{},
+	}
+	for i, ptr := range ptrs {
+		refs, err := parsePointer(ptr)
+		if err != nil {
+			return nil, err
+		}
+		n := s.root
+		for _, ref := range refs {
+			n = n.child(ref)
+		}
+		n.match = append(n.match, i)
+	}
+	s.root.index()
+	return s, nil
+}
+
+// index fills indexes of n and its children.
+func (n *pointerNode) index() {
+	for ref, c := range n.keys {
+		if i, ok := pointerIndex(ref); ok {
+			if n.indexes == nil {
+				n.indexes = map[int]*pointerNode{}
+			}
+			n.indexes[i] = c
+		}
+		c.index()
+	}
+}
+
+// parsePointer splits json pointer into unescaped reference tokens.
+func parsePointer(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if ptr[0] != '/' {
+		return nil, errors.Errorf("invalid pointer %q: must start with %q", ptr, '/')
+	}
+	refs := strings.Split(ptr[1:], "/")
+	for i, ref := range refs {
+		v, err := unescapePointer(ref)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = v
+	}
+	return refs, nil
+}
+
+// Len returns count of pointers in set.
+func (s *PointerSet) Len() int {
+	return len(s.ptrs)
+}
+
+// Pointer returns i-th pointer of set.
+func (s *PointerSet) Pointer(i int) string {
+	return s.ptrs[i]
+}
+
+// Extract reads single value from decoder, calling f for every value
+// addressed by pointers of set, where i is index of pointer.
+//
+// Callback must consume the value. Everything else is skipped. Callback is
+// called for every occurrence of duplicate key.
+func (s *PointerSet) Extract(d *Decoder, f func(d *Decoder, i int) error) error {
+	return s.walk(d, s.root, f)
+}
+
+// ExtractRaw is Extract that saves addressed values to slots of dst,
+// growing it to Len if needed.
+//
+// Slots are reused. Slot of value that was not found is empty.
+func (s *PointerSet) ExtractRaw(d *Decoder, dst []Raw) ([]Raw, error) {
+	for len(dst) < len(s.ptrs) {
+		dst = append(dst, nil)
+	}
+	for i := range dst {
+		dst[i] = dst[i][:0]
+	}
+	err := s.Extract(d, func(d *Decoder, i int) error {
+		raw, err := d.Raw()
+		if err != nil {
+			return err
+		}
+		dst[i] = append(dst[i][:0], raw...)
+		return nil
+	})
+	return dst, err
+}
+
+func (s *PointerSet) walk(d *Decoder, n *pointerNode, f func(d *Decoder, i int) error) error {
+	if len(n.match) > 0 {
+		if len(n.match) == 1 && len(n.keys) == 0 {
+			return f(d, n.match[0])
+		}
+		// Value is needed more than once, so read it and then decode
+		// every time from the copy.
+		raw, err := d.rawStable()
+		if err != nil {
+			return err
+		}
+		for _, i := range n.match {
+			if err := f(d.rawDecoder(raw), i); err != nil {
+				return err
+			}
+		}
+		if len(n.keys) == 0 {
+			return nil
+		}
+		return s.walk(d.rawDecoder(raw), &pointerNode{keys: n.keys, indexes: n.indexes}, f)
+	}
+	switch d.Next() {
+	case Object:
+		if len(n.keys) == 0 {
+			return d.Skip()
+		}
+		return d.ObjBytes(func(d *Decoder, key []byte) error {
+			c, ok := n.keys[string(key)]
+			if !ok {
+				return d.Skip()
+			}
+			return s.walk(d, c, f)
+		})
+	case Array:
+		if len(n.indexes) == 0 {
+			return d.Skip()
+		}
+		i := 0
+		return d.Arr(func(d *Decoder) error {
+			c, ok := n.indexes[i]
+			i++
+			if !ok {
+				return d.Skip()
+			}
+			return s.walk(d, c, f)
+		})
+	default:
+		return d.Skip()
+	}
+}
@@ -0,0 +1,111 @@
+package jx
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointerSet(t *testing.T) {
+	const input = `{
+	"id": 1,
+	"user": {"name": "alice", "tags": ["a", "b"]},
+	"items": [{"id": 10}, {"id": 20}, {"id": 30}],
+	"0": "key",
+	"skip": {"deep": [[[{}]]]}
+}`
+	set, err := NewPointerSet(
+		"/id",
+		"/user/name",
+		"/user/tags/1",
+		"/items/2/id",
+		"/items/0",
+		"/items/0/id",
+		"/missing",
+		"/0",
+		"",
+		"/id",
+	)
+	require.NoError(t, err)
+	require.Equal(t, 10, set.Len())
+	require.Equal(t, "/user/name", set.Pointer(1))
+
+	expected := []string{
+		`1`,
+		`"alice"`,
+		`"b"`,
+		`30`,
+		`{"id": 10}`,
+		`10`,
+		``,
+		`"key"`,
+		input,
+		`1`,
+	}
+	t.Run("ExtractRaw", testBufferReader(input, func(t *testing.T, d *Decoder) {
+		raws, err := set.ExtractRaw(d, nil)
+		require.NoError(t, err)
+		require.Len(t, raws, len(expected))
+		for i, raw := range raws {
+			require.Equal(t, expected[i], raw.String(), set.Pointer(i))
+		}
+		// Whole value is consumed.
+		require.ErrorIs(t, d.Skip(), io.EOF)
+	}))
+	t.Run("Extract", testBufferReader(input, func(t *testing.T, d *Decoder) {
+		got := map[int]string{}
+		require.NoError(t, set.Extract(d, func(d *Decoder, i int) error {
+			raw, err := d.Raw()
+			if err != nil {
+				return err
+			}
+			got[i] = raw.String()
+			return nil
+		}))
+		for i, v := range expected {
+			if v == "" {
+				require.NotContains(t, got, i)
+				continue
+			}
+			require.Equal(t, v, got[i], set.Pointer(i))
+		}
+	}))
+	t.Run("Stream", func(t *testing.T) {
+		set, err := NewPointerSet("/a", "/b/0")
+		require.NoError(t, err)
+
+		d := DecodeStr("{\"a\": 1, \"b\": [2]}\n{\"b\": [3, 4]}\n{\"a\": 5}")
+		var (
+			raws []Raw
+			got  [][]string
+		)
+		iter := d.StreamIter()
+		for iter.Next() {
+			raws, err = set.ExtractRaw(d, raws)
+			require.NoError(t, err)
+			got = append(got, []string{raws[0].String(), raws[1].String()})
+		}
+		require.NoError(t, iter.Err())
+		require.Equal(t, [][]string{{`1`, `2`}, {``, `3`}, {`5`, ``}}, got)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewPointerSet("/a", "b")
+		require.Error(t, err)
+		_, err = NewPointerSet("/a~2")
+		require.Error(t, err)
+
+		set, err := NewPointerSet("/a")
+		require.NoError(t, err)
+		_, err = set.ExtractRaw(DecodeStr(`{"b": [1, }`), nil)
+		require.Error(t, err)
+	})
+	t.Run("Depth", func(t *testing.T) {
+		set, err := NewPointerSet("/0", "/0/0")
+		require.NoError(t, err)
+		d := DecodeStr(`[[[[1]]]]`)
+		d.SetMaxDepth(3)
+		_, err = set.ExtractRaw(d, nil)
+		require.ErrorIs(t, err, ErrMaxDepth)
+	})
+}
@@ -0,0 +1,110 @@
+package jx
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_Pointer(t *testing.T) {
+	// Example document from RFC 6901, Section 5.
+	const input = `{
+	"foo": ["bar", "baz"],
+	"": 0,
+	"a/b": 1,
+	"c%d": 2,
+	"e^f": 3,
+	"g|h": 4,
+	"i\\j": 5,
+	"k\"l": 6,
+	" ": 7,
+	"m~n": 8,
+	"nested": {"items": [{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4, "tags": []}]}
+}`
+	for _, tt := range []struct {
+		Pointer string
+		Output  string
+	}{
+		{"", input},
+		{"/foo", `["bar", "baz"]`},
+		{"/foo/0", `"bar"`},
+		{"/foo/1", `"baz"`},
+		{"/", `0`},
+		{"/a~1b", `1`},
+		{"/c%d", `2`},
+		{"/e^f", `3`},
+		{"/g|h", `4`},
+		{"/i\\j", `5`},
+		{"/k\"l", `6`},
+		{"/ ", `7`},
+		{"/m~0n", `8`},
+		{"/nested/items/3/id", `4`},
+		{"/nested/items/3/tags", `[]`},
+	} {
+		tt := tt
+		t.Run(tt.Pointer, testBufferReader(input, func(t *testing.T, d *Decoder) {
+			require.NoError(t, d.Pointer(tt.Pointer))
+			raw, err := d.Raw()
+			require.NoError(t, err)
+			require.Equal(t, tt.Output, raw.String())
+		}))
+	}
+	t.Run("NotFound", func(t *testing.T) {
+		for _, ptr := range []string{
+			"/bar",
+			"/foo/2",
+			"/foo/-",
+			"/foo/01",
+			"/foo/-1",
+			"/foo/bar",
+			"/foo/0/bar",
+			"/a/b",
+			"/nested/items/99999999999",
+		} {
+			ptr := ptr
+			t.Run(ptr, testBufferReader(input, func(t *testing.T, d *Decoder) {
+				require.ErrorIs(t, d.Pointer(ptr), ErrPointerNotFound)
+			}))
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, tt := range []struct {
+			Input   string
+			Pointer string
+		}{
+			{`{}`, "foo"},
+			{`{"a~b": 1}`, "/a~b"},
+			{`{"a~": 1}`, "/a~"},
+			{`{"a": 1`, "/b"},
+			{`[1, 2`, "/2"},
+			{`{"a": }`, "/a/b"},
+			{``, "/a"},
+			{``, ""},
+		} {
+			d := DecodeStr(tt.Input)
+			err := d.Pointer(tt.Pointer)
+			require.Error(t, err, tt.Pointer)
+			require.NotErrorIs(t, err, ErrPointerNotFound, tt.Pointer)
+		}
+	})
+	t.Run("Depth", func(t *testing.T) {
+		d := DecodeStr(`[[[1]]]`)
+		d.SetMaxDepth(2)
+		require.ErrorIs(t, d.Pointer("/0/0/0"), ErrMaxDepth)
+	})
+	t.Run("UnexpectedEOF", func(t *testing.T) {
+		d := DecodeStr(`{"a": [1, 2]`)
+		require.ErrorIs(t, d.Pointer("/b"), io.ErrUnexpectedEOF)
+	})
+}
+
+func TestRaw_Pointer(t *testing.T) {
+	raw := Raw(`{"data": {"items": [{"id": 1}, {"id": "x"}]}} trailing`)
+	v, err := raw.Pointer("/data/items/1/id")
+	require.NoError(t, err)
+	require.Equal(t, `"x"`, v.String())
+
+	_, err = raw.Pointer("/data/items/2")
+	require.ErrorIs(t, err, ErrPointerNotFound)
+}
@@ -31,6 +31,9 @@ func (r *rawReader) Read(p []byte) (n int, err error) {
 //
 // Do not retain returned value, it references underlying buffer.
 func (d *Decoder) Raw() (Raw, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		return d.raw5()
+	}
 	start := d.head
 	if orig := d.reader; orig != nil {
 		rr := &rawReader{
@@ -60,6 +63,33 @@ func (d *Decoder) Raw() (Raw, error) {
 	return d.buf[start:d.head], nil
 }
 
+// rawStable is Raw that returns value without leading whitespace, which is
+// not overwritten by subsequent reads.
+func (d *Decoder) rawStable() (Raw, error) {
+	if err := d.skipSpace(); err != nil {
+		return nil, err
+	}
+	raw, err := d.Raw()
+	if err != nil {
+		return nil, err
+	}
+	if d.reader != nil {
+		raw = append(Raw(nil), raw...)
+	}
+	return raw, nil
+}
+
+// rawDecoder returns decoder of raw value read from d, inheriting options
+// and depth of d.
+func (d *Decoder) rawDecoder(raw Raw) *Decoder {
+	return &Decoder{
+		buf:   raw,
+		tail:  len(raw),
+		depth: d.depth,
+		opts:  d.opts,
+	}
+}
+
 // RawAppend is Raw that appends saved raw json value to buf.
 func (d *Decoder) RawAppend(buf Raw) (Raw, error) {
 	raw, err := d.Raw()
@@ -11,6 +11,9 @@ func (d *Decoder) Next() Type {
 	if err == nil {
 		d.unread()
 	}
+	if d.opts.syntax == SyntaxJSON5 {
+		return types5[v]
+	}
 	return types[v]
 }
 
@@ -19,13 +22,25 @@ var spaceSet = [256]byte{
 }
 
 func (d *Decoder) consume(c byte) (err error) {
+readBuf:
 	for {
 		buf := d.buf[d.head:d.tail]
 		for i, got := range buf {
 			switch spaceSet[got] {
 			default:
 				if c != got {
-					return badToken(got, d.offset()+i)
+					if d.relaxed() {
+						d.head += i + 1
+						ok, err := d.skipExtra(got)
+						if err != nil {
+							return err
+						}
+						if ok {
+							continue readBuf
+						}
+						d.head -= i + 1
+					}
+					return d.badToken(got, d.offset()+i)
 				}
 				d.head += i + 1
 				return nil
@@ -53,14 +68,29 @@ func (d *Decoder) more() (byte, error) {
 
 // next reads next non-whitespace token or error.
 func (d *Decoder) next() (byte, error) {
+readBuf:
 	for {
 		buf := d.buf[d.head:d.tail]
 		for i, c := range buf {
 			switch spaceSet[c] {
 			default:
 				d.head += i + 1
+				if d.relaxed() {
+					ok, err := d.skipExtra(c)
+					if err != nil {
+						return 0, err
+					}
+					if ok {
+						continue readBuf
+					}
+				}
 				return c, nil
 			case 1:
+				if i == 16 {
+					// Long whitespace run, like indentation.
+					d.head += i + scanSpace(buf[i:])
+					continue readBuf
+				}
 				continue
 			}
 		}
@@ -102,6 +132,9 @@ func (d *Decoder) read() error {
 		return io.EOF
 	}
 
+	line, lineStart := d.trackLines()
+	mark := d.mark
+	d.mark.save(d.streamOffset, d.buf[:d.tail])
 	n, err := d.reader.Read(d.buf)
 	switch err {
 	case nil:
@@ -111,10 +144,12 @@ func (d *Decoder) read() error {
 		}
 		fallthrough
 	default:
+		d.mark = mark
 		return err
 	}
 
 	d.streamOffset += d.tail
+	d.line, d.lineStart = line, lineStart
 	d.head = 0
 	d.tail = n
 	return nil
@@ -126,11 +161,15 @@ func (d *Decoder) readAtLeast(n int) error {
 		return io.ErrUnexpectedEOF
 	}
 
+	line, lineStart := d.trackLines()
+	mark := d.mark
+	d.mark.save(d.streamOffset, d.buf[:d.tail])
 	if need := n - len(d.buf); need > 0 {
 		d.buf = append(d.buf, make([]byte, need)...)
 	}
 	n, err := io.ReadAtLeast(d.reader, d.buf, n)
 	if err != nil {
+		d.mark = mark
 		if err == io.EOF && n == 0 {
 			return io.ErrUnexpectedEOF
 		}
@@ -138,6 +177,7 @@ func (d *Decoder) readAtLeast(n int) error {
 	}
 
 	d.streamOffset += d.tail
+	d.line, d.lineStart = line, lineStart
 	d.head = 0
 	d.tail = n
 	return nil
@@ -159,8 +199,8 @@ func (d *Decoder) readExact4(b *[4]byte) error {
 	return nil
 }
 
-func findInvalidToken4(buf [4]byte, mask uint32, offset int) error {
+func (d *Decoder) findInvalidToken4(buf [4]byte, mask uint32, offset int) error {
 	c := uint32(buf[0]) | uint32(buf[1])<<8 | uint32(buf[2])<<16 | uint32(buf[3])<<24
 	idx := bits.TrailingZeros32(c^mask) / 8
-	return badToken(buf[idx], offset+idx)
+	return d.badToken(buf[idx], offset+idx)
 }
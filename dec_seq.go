@@ -0,0 +1,162 @@
+package jx
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/go-faster/errors"
+)
+
+// ErrTruncatedRecord means that json text sequence record is a top-level
+// number, true, false or null not followed by whitespace, so it might be
+// truncated.
+//
+// See RFC 7464, Section 2.4.
+var ErrTruncatedRecord = errors.New("seq: truncated record")
+
+var errMissingRS = errors.New("seq: data before first record separator")
+
+// SeqIter is iterator over records of RFC 7464 json text sequence
+// (application/json-seq).
+//
+// Every record is RS-delimited, so malformed record does not affect
+// subsequent ones: iteration continues with the next record.
+type SeqIter struct {
+	d      *Decoder
+	err    error
+	closed bool
+	index  int
+	offset int
+
+	buf     []byte // for reader, buffer of current record
+	text    []byte // json text of current record, whitespace trimmed
+	trailWS bool   // whether text is followed by whitespace
+	noRS    bool   // whether record is not preceded by record separator
+	started bool   // whether first element was read
+}
+
+// SeqIter creates new iterator over json text sequence records.
+func (d *Decoder) SeqIter() SeqIter {
+	return SeqIter{
+		d:     d,
+		index: -1,
+	}
+}
+
+// Next reads next non-empty record and returns false, if there is no
+// records anymore.
+func (i *SeqIter) Next() bool {
+	if i.closed || i.err != nil {
+		return false
+	}
+
+	dec := i.d
+	for {
+		start := dec.offset()
+		elem, err := dec.seqElem(i.buf[:0])
+		if dec.reader != nil {
+			i.buf = elem
+		}
+		if err != nil {
+			i.closed = true
+			if err != io.EOF {
+				i.err = err
+			}
+			return false
+		}
+		// Text before first record separator is not a record, but it
+		// is reported to make data loss visible.
+		noRS := !i.started
+		i.started = true
+
+		text := bytes.TrimLeft(elem, " \t\r\n")
+		leading := len(elem) - len(text)
+		text = bytes.TrimRight(text, " \t\r\n")
+		if len(text) == 0 {
+			// Empty sequence element, e.g. consecutive record separators.
+			continue
+		}
+
+		i.index++
+		i.offset = start + leading
+		i.text = text
+		i.trailWS = leading+len(text) < len(elem)
+		i.noRS = noRS
+		return true
+	}
+}
+
+// Index returns zero-based index of current record.
+func (i *SeqIter) Index() int {
+	return i.index
+}
+
+// Offset returns input offset of current record json text.
+func (i *SeqIter) Offset() int {
+	return i.offset
+}
+
+// Raw validates current record and returns its json text.
+//
+// Returns ErrTruncatedRecord if record might be truncated. Decoding
+// errors offsets are relative to the record json text.
+//
+// Do not retain returned value, it references underlying buffer.
+func (i *SeqIter) Raw() (Raw, error) {
+	if i.noRS {
+		return nil, errMissingRS
+	}
+	d := Decoder{opts: i.d.opts}
+	d.ResetBytes(i.text)
+
+	typ := d.Next()
+	if err := d.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validate")
+	}
+	switch typ {
+	case Number, Bool, Null:
+		if !i.trailWS {
+			return nil, ErrTruncatedRecord
+		}
+	}
+	return i.text, nil
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (i *SeqIter) Err() error {
+	return i.err
+}
+
+// seqElem reads json text sequence element up to next record separator
+// or EOF, consuming separator.
+//
+// Returns io.EOF if there is no input left.
+func (d *Decoder) seqElem(b []byte) ([]byte, error) {
+	if _, err := d.peek(); err != nil {
+		return b, err
+	}
+	if d.reader == nil {
+		buf := d.buf[d.head:d.tail]
+		if i := bytes.IndexByte(buf, seqRS); i >= 0 {
+			d.head += i + 1
+			return buf[:i], nil
+		}
+		d.head = d.tail
+		return buf, nil
+	}
+	for {
+		buf := d.buf[d.head:d.tail]
+		if i := bytes.IndexByte(buf, seqRS); i >= 0 {
+			d.head += i + 1
+			return append(b, buf[:i]...), nil
+		}
+		b = append(b, buf...)
+		d.head = d.tail
+		if err := d.read(); err != nil {
+			if err == io.EOF {
+				return b, nil
+			}
+			return b, err
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package jx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_SeqIter(t *testing.T) {
+	type record struct {
+		Index  int
+		Offset int
+		Raw    string
+		Err    bool
+	}
+	collect := func(t *testing.T, d *Decoder) (records []record) {
+		iter := d.SeqIter()
+		for iter.Next() {
+			raw, err := iter.Raw()
+			records = append(records, record{
+				Index:  iter.Index(),
+				Offset: iter.Offset(),
+				Raw:    raw.String(),
+				Err:    err != nil,
+			})
+		}
+		require.NoError(t, iter.Err())
+		require.False(t, iter.Next())
+		return records
+	}
+	t.Run("Valid", testBufferReader("\x1e{\"a\":1}\n\x1e[1,\n 2]\n\x1e\"s\"\n\x1e1\n", func(t *testing.T, d *Decoder) {
+		require.Equal(t, []record{
+			{0, 1, `{"a":1}`, false},
+			{1, 10, "[1,\n 2]", false},
+			{2, 19, `"s"`, false},
+			{3, 24, `1`, false},
+		}, collect(t, d))
+	}))
+	t.Run("Empty", testBufferReader("\x1e\x1e \n\x1e", func(t *testing.T, d *Decoder) {
+		require.Empty(t, collect(t, d))
+	}))
+	t.Run("Recover", testBufferReader(
+		"garbage\n\x1e{\"a\":\x1e[1]\n\x1e123\x1etrue\x1enull \x1e[1] 2\n\x1e\"s\"",
+		func(t *testing.T, d *Decoder) {
+			require.Equal(t, []record{
+				// Data before first RS.
+				{0, 0, ``, true},
+				// Truncated object.
+				{1, 9, ``, true},
+				{2, 15, `[1]`, false},
+				// Possibly truncated number and literal.
+				{3, 20, ``, true},
+				{4, 24, ``, true},
+				{5, 29, `null`, false},
+				// Trailing data.
+				{6, 35, ``, true},
+				{7, 42, `"s"`, false},
+			}, collect(t, d))
+		},
+	))
+	t.Run("Truncated", func(t *testing.T) {
+		iter := DecodeStr("\x1e123").SeqIter()
+		require.True(t, iter.Next())
+		_, err := iter.Raw()
+		require.ErrorIs(t, err, ErrTruncatedRecord)
+	})
+}
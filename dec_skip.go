@@ -8,6 +8,9 @@ import (
 
 // Skip skips a json object and positions to relatively the next json object.
 func (d *Decoder) Skip() error {
+	if d.opts.syntax == SyntaxJSON5 {
+		return d.value5(nil)
+	}
 	c, err := d.next()
 	if err != nil {
 		return err
@@ -39,8 +42,17 @@ func (d *Decoder) Skip() error {
 		}
 		return nil
 	default:
-		return badToken(c, d.offset()-1)
+		return d.badToken(c, d.offset()-1)
+	}
+}
+
+// skipMore is Skip, but io.EOF is unexpected.
+func (d *Decoder) skipMore() error {
+	err := d.Skip()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
 	}
+	return err
 }
 
 var skipNumberSet = [256]byte{
@@ -82,7 +94,7 @@ func (d *Decoder) skipNumber() error {
 		}
 		// Character after '-' must be a digit.
 		if skipNumberSet[c] != digitTag {
-			return badToken(c, d.offset()-1)
+			return d.badToken(c, d.offset()-1)
 		}
 		if c != '0' {
 			break
@@ -111,7 +123,10 @@ func (d *Decoder) skipNumber() error {
 		case 'e', 'E':
 			goto stateExp
 		default:
-			return badToken(c, d.offset())
+			if d.commentStart(c) {
+				return nil
+			}
+			return d.badToken(c, d.offset())
 		}
 	}
 	for {
@@ -132,7 +147,11 @@ func (d *Decoder) skipNumber() error {
 				d.head += i
 				goto stateExp
 			default:
-				return badToken(c, d.offset()+i)
+				if d.commentStart(c) {
+					d.head += i
+					return nil
+				}
+				return d.badToken(c, d.offset()+i)
 			}
 		}
 
@@ -168,12 +187,16 @@ stateDot:
 				switch c {
 				case 'e', 'E':
 					if last == '.' {
-						return badToken(c, d.offset()+i)
+						return d.badToken(c, d.offset()+i)
 					}
 					d.head += i
 					goto stateExp
 				default:
-					return badToken(c, d.offset()+i)
+					if d.commentStart(c) && last != '.' {
+						d.head += i
+						return nil
+					}
+					return d.badToken(c, d.offset()+i)
 				}
 			}
 
@@ -207,10 +230,10 @@ stateExp:
 				}
 				// There must be a number after sign.
 				if skipNumberSet[num] != digitTag {
-					return badToken(num, d.offset()-1)
+					return d.badToken(num, d.offset()-1)
 				}
 			} else {
-				return badToken(numOrSign, d.offset()-1)
+				return d.badToken(numOrSign, d.offset()-1)
 			}
 		}
 	}
@@ -221,7 +244,11 @@ stateExp:
 				return nil
 			}
 			if skipNumberSet[c] == 0 {
-				return badToken(c, d.offset()+i)
+				if d.commentStart(c) {
+					d.head += i
+					return nil
+				}
+				return d.badToken(c, d.offset()+i)
 			}
 		}
 
@@ -265,6 +292,9 @@ var (
 //
 // Assumes first quote was consumed.
 func (d *Decoder) skipStr() error {
+	if d.opts.utf8 == UTF8Strict {
+		return d.skipStrStrict()
+	}
 	var (
 		c byte
 		i int
@@ -274,6 +304,17 @@ readStr:
 		i = 0
 		buf := d.buf[d.head:d.tail]
 		for len(buf) >= 8 {
+			if i == 64 {
+				// Long string, use vectorized scan for the rest.
+				n := scanStr(buf)
+				if i += n; n < len(buf) {
+					c = buf[n]
+					goto readTok
+				}
+				buf = nil
+				break
+			}
+
 			c = buf[0]
 			if safeSet[c] != 0 {
 				goto readTok
@@ -360,14 +401,14 @@ readTok:
 					return err
 				}
 				if hexSet[h] == 0 {
-					return badToken(h, d.offset()-1)
+					return classify(ErrInvalidEscape, d.badToken(h, d.offset()-1))
 				}
 			}
 		case 0:
-			return badToken(v, d.offset()-1)
+			return classify(ErrInvalidEscape, d.badToken(v, d.offset()-1))
 		}
 	case c < ' ':
-		return badToken(c, d.offset()+i)
+		return d.badToken(c, d.offset()+i)
 	}
 	goto readStr
 }
@@ -376,9 +417,13 @@ readTok:
 //
 // Assumes first bracket was consumed.
 func (d *Decoder) skipObj() error {
+	if d.opts.syntax == SyntaxJSON5 {
+		return d.obj5(nil)
+	}
 	if err := d.incDepth(); err != nil {
 		return errors.Wrap(err, "inc")
 	}
+	d.objStart()
 
 	c, err := d.more()
 	if err != nil {
@@ -390,20 +435,20 @@ func (d *Decoder) skipObj() error {
 	case '"':
 		d.unread()
 	default:
-		return badToken(c, d.offset()-1)
+		return d.badToken(c, d.offset()-1)
 	}
 
 	for {
 		if err := d.consume('"'); err != nil {
 			return errors.Wrap(err, `'"' expected`)
 		}
-		if err := d.skipStr(); err != nil {
+		if err := d.skipObjKey(); err != nil {
 			return errors.Wrap(err, "read field name")
 		}
 		if err := d.consume(':'); err != nil {
 			return errors.Wrap(err, `":" expected`)
 		}
-		if err := d.Skip(); err != nil {
+		if err := d.skipMore(); err != nil {
 			return err
 		}
 		c, err := d.more()
@@ -412,11 +457,18 @@ func (d *Decoder) skipObj() error {
 		}
 		switch c {
 		case ',':
+			end, err := d.trailingComma('}')
+			if err != nil {
+				return err
+			}
+			if end {
+				return d.decDepth()
+			}
 			continue
 		case '}':
 			return d.decDepth()
 		default:
-			return badToken(c, d.offset()-1)
+			return d.badToken(c, d.offset()-1)
 		}
 	}
 }
@@ -425,6 +477,9 @@ func (d *Decoder) skipObj() error {
 //
 // Assumes first bracket was consumed.
 func (d *Decoder) skipArr() error {
+	if d.opts.syntax == SyntaxJSON5 {
+		return d.arr5(nil)
+	}
 	if err := d.incDepth(); err != nil {
 		return errors.Wrap(err, "inc")
 	}
@@ -439,7 +494,7 @@ func (d *Decoder) skipArr() error {
 	d.unread()
 
 	for {
-		if err := d.Skip(); err != nil {
+		if err := d.skipMore(); err != nil {
 			return err
 		}
 		c, err := d.more()
@@ -448,11 +503,18 @@ func (d *Decoder) skipArr() error {
 		}
 		switch c {
 		case ',':
+			end, err := d.trailingComma(']')
+			if err != nil {
+				return err
+			}
+			if end {
+				return d.decDepth()
+			}
 			continue
 		case ']':
 			return d.decDepth()
 		default:
-			return badToken(c, d.offset()-1)
+			return d.badToken(c, d.offset()-1)
 		}
 	}
 }
@@ -76,7 +76,7 @@ func TestDecoder_skipNumber(t *testing.T) {
 
 func TestDecoder_SkipObjDepth(t *testing.T) {
 	var input []byte
-	for i := 0; i <= maxDepth; i++ {
+	for i := 0; i <= defaultMaxDepth; i++ {
 		input = append(input, `{"1":`...)
 	}
 	require.Error(t, DecodeBytes(input).Skip())
@@ -34,9 +34,19 @@ func (v value) rune(r rune) value {
 }
 
 func (d *Decoder) str(v value) (value, error) {
+	if d.opts.syntax == SyntaxJSON5 {
+		return d.str5(v)
+	}
 	if err := d.consume('"'); err != nil {
 		return value{}, err
 	}
+	return d.strBody(v)
+}
+
+// strBody reads string.
+//
+// Assumes first quote was consumed.
+func (d *Decoder) strBody(v value) (value, error) {
 	var (
 		c byte
 		i int
@@ -112,16 +122,22 @@ readTok:
 		// Skip string + last quote.
 		d.head += i + 1
 		if v.raw {
-			return value{buf: str, raw: true}, nil
+			return d.checkUTF8(value{buf: str, raw: true}, 0, d.offset()-1)
 		}
-		return value{buf: append(v.buf, str...)}, nil
+		from := len(v.buf)
+		return d.checkUTF8(value{buf: append(v.buf, str...)}, from, d.offset()-1)
 	case c == '\\':
 		// Skip only string, keep quote in buffer.
 		d.head += i
 		// We need a copy anyway, because string is escaped.
-		return d.strSlow(value{buf: append(v.buf, str...)})
+		from := len(v.buf)
+		v, err := d.checkUTF8(value{buf: append(v.buf, str...)}, from, d.offset())
+		if err != nil {
+			return v, err
+		}
+		return d.strSlow(v)
 	default:
-		return v, badToken(c, d.offset()+i)
+		return v, d.badToken(c, d.offset()+i)
 	}
 }
 
@@ -129,6 +145,9 @@ func (d *Decoder) strSlow(v value) (value, error) {
 	var (
 		c byte
 		i int
+		// checked is length of v.buf prefix that is already checked
+		// to be valid UTF-8.
+		checked = len(v.buf)
 	)
 readStr:
 	for {
@@ -207,10 +226,14 @@ readTok:
 
 	switch {
 	case c == '"':
-		return value{buf: append(v.buf, str...)}, nil
+		return d.checkUTF8(value{buf: append(v.buf, str...)}, checked, d.offset()-1)
 	case c == '\\':
-		v.buf = append(v.buf, str...)
-		c, err := d.byte()
+		var err error
+		v, err = d.checkUTF8(value{buf: append(v.buf, str...)}, checked, d.offset()-1)
+		if err != nil {
+			return v, err
+		}
+		c, err = d.byte()
 		if err != nil {
 			return value{}, err
 		}
@@ -218,8 +241,9 @@ readTok:
 		if err != nil {
 			return v, errors.Wrap(err, "escape")
 		}
+		checked = len(v.buf)
 	default:
-		return v, badToken(c, d.offset()-1)
+		return v, d.badToken(c, d.offset()-1)
 	}
 	goto readStr
 }
@@ -249,6 +273,8 @@ func (d *Decoder) escapedChar(v value, c byte) (value, error) {
 	default:
 		v.buf = append(v.buf, val)
 	case 'u':
+		// Offset of escape start.
+		offset := d.offset() - 2
 		r1, err := d.readU4()
 		if err != nil {
 			return value{}, errors.Wrap(err, "read u4")
@@ -260,14 +286,18 @@ func (d *Decoder) escapedChar(v value, c byte) (value, error) {
 			}
 			if c != '\\' {
 				d.unread()
-				return v.rune(r1), nil
+				return d.loneSurrogate(v, r1, offset)
 			}
 			c, err = d.byte()
 			if err != nil {
 				return value{}, err
 			}
 			if c != 'u' {
-				return d.escapedChar(v.rune(r1), c)
+				v, err := d.loneSurrogate(v, r1, offset)
+				if err != nil {
+					return v, err
+				}
+				return d.escapedChar(v, c)
 			}
 			r2, err := d.readU4()
 			if err != nil {
@@ -275,7 +305,13 @@ func (d *Decoder) escapedChar(v value, c byte) (value, error) {
 			}
 			combined := utf16.DecodeRune(r1, r2)
 			if combined == '\uFFFD' {
-				v = v.rune(r1).rune(r2)
+				if v, err = d.loneSurrogate(v, r1, offset); err != nil {
+					return v, err
+				}
+				if utf16.IsSurrogate(r2) {
+					return d.loneSurrogate(v, r2, offset+6)
+				}
+				v = v.rune(r2)
 			} else {
 				v = v.rune(combined)
 			}
@@ -283,7 +319,7 @@ func (d *Decoder) escapedChar(v value, c byte) (value, error) {
 			v = v.rune(r1)
 		}
 	case 0:
-		err := badToken(c, d.offset()-1)
+		err := classify(ErrInvalidEscape, d.badToken(c, d.offset()-1))
 		return v, errors.Wrap(err, "bad escape")
 	}
 	return v, nil
@@ -300,7 +336,7 @@ func (d *Decoder) readU4() (v rune, _ error) {
 	for i, c := range b {
 		val := hexSet[c]
 		if val == 0 {
-			return 0, badToken(c, offset+i)
+			return 0, classify(ErrInvalidEscape, d.badToken(c, offset+i))
 		}
 		v = v*16 + rune(val-1)
 	}
@@ -0,0 +1,191 @@
+package jx
+
+import (
+	"bytes"
+	"io"
+)
+
+// StreamIter is iterator over top-level values of json stream, like
+// newline-delimited json (NDJSON) or concatenated json values.
+//
+// Value must be consumed, e.g. by Skip or Raw, before calling Next again.
+type StreamIter struct {
+	d      *Decoder
+	err    error
+	closed bool
+	index  int
+	offset int
+	depth  int
+}
+
+// StreamIter creates new iterator over top-level values.
+func (d *Decoder) StreamIter() StreamIter {
+	return StreamIter{
+		d:     d,
+		index: -1,
+		depth: d.depth,
+	}
+}
+
+// Next skips whitespace to start of next value and returns false, if there
+// is no values anymore.
+func (i *StreamIter) Next() bool {
+	if i.closed || i.err != nil {
+		return false
+	}
+
+	dec := i.d
+	// Previous value is consumed, its input is not needed anymore.
+	dec.mark.reset()
+	if _, err := dec.next(); err != nil {
+		i.closed = true
+		if err != io.EOF {
+			i.err = err
+		}
+		dec.mark.release()
+		return false
+	}
+	dec.unread()
+	i.index++
+	i.offset = dec.offset()
+	if dec.reader != nil {
+		dec.mark.start(i.offset)
+	}
+	return true
+}
+
+// Index returns zero-based index of current value.
+func (i *StreamIter) Index() int {
+	return i.index
+}
+
+// Offset returns input offset of current value.
+func (i *StreamIter) Offset() int {
+	return i.offset
+}
+
+// Recover recovers from failed decoding of current value by skipping input
+// up to first newline after start of the value, so iteration continues with
+// value on next line.
+//
+// Other values on the same line are skipped too. Input after the newline is
+// decoded again, even if it was consumed by failed decoding. If more than
+// 64KB of such input was consumed by decoder with reader, it is not kept and
+// Recover skips input up to next newline instead.
+func (i *StreamIter) Recover() error {
+	dec := i.d
+	dec.depth = i.depth
+
+	switch m := &dec.mark; {
+	case m.found && m.lost:
+		m.reset()
+		if err := dec.skipLine(); err != nil {
+			i.err = err
+			return err
+		}
+		return nil
+	case m.found:
+		// Newline is not in buffer anymore, read kept input again.
+		dec.rewind()
+		return nil
+	}
+	start := i.offset - dec.streamOffset
+	if start < 0 {
+		start = 0
+	}
+	if idx := bytes.IndexByte(dec.buf[start:dec.tail], '\n'); idx >= 0 {
+		dec.head = start + idx + 1
+		return nil
+	}
+	dec.head = dec.tail
+	if err := dec.skipLine(); err != nil {
+		i.err = err
+		return err
+	}
+	return nil
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (i *StreamIter) Err() error {
+	return i.err
+}
+
+// lineMarkLimit is maximum length of input kept by lineMark.
+const lineMarkLimit = 64 << 10
+
+// lineMark keeps input discarded by reads after first newline following
+// start of stream value, so StreamIter.Recover can continue from there.
+type lineMark struct {
+	active bool
+	offset int    // offset in stream to start of value
+	found  bool   // whether newline was discarded
+	lost   bool   // whether discarded input exceeded lineMarkLimit
+	rest   []byte // discarded input after newline
+}
+
+func (m *lineMark) start(offset int) {
+	m.reset()
+	m.active = true
+	m.offset = offset
+}
+
+func (m *lineMark) reset() {
+	m.active = false
+	m.found = false
+	m.lost = false
+	m.rest = m.rest[:0]
+}
+
+// release resets mark and frees kept input.
+func (m *lineMark) release() {
+	m.reset()
+	m.rest = nil
+}
+
+// save keeps buf, which starts at given stream offset, before it is
+// overwritten by read.
+func (m *lineMark) save(offset int, buf []byte) {
+	if !m.active || m.lost {
+		return
+	}
+	if !m.found {
+		start := m.offset - offset
+		if start < 0 {
+			start = 0
+		}
+		if start > len(buf) {
+			return
+		}
+		idx := bytes.IndexByte(buf[start:], '\n')
+		if idx < 0 {
+			return
+		}
+		m.found = true
+		buf = buf[start+idx+1:]
+	}
+	if len(m.rest)+len(buf) > lineMarkLimit {
+		m.lost = true
+		m.rest = nil
+		return
+	}
+	m.rest = append(m.rest, buf...)
+}
+
+// rewind continues reading from kept input, which is followed by the rest
+// of current buffer and reader.
+func (d *Decoder) rewind() {
+	m := &d.mark
+	// Current buffer is overwritten by next read, keep it too.
+	rest := append(m.rest, d.buf[:d.tail]...)
+	d.reader = io.MultiReader(bytes.NewReader(rest), d.reader)
+
+	kept := len(m.rest)
+	d.streamOffset -= kept
+	d.line -= bytes.Count(m.rest, []byte{'\n'})
+	d.lineStart = d.streamOffset
+	d.head, d.tail = 0, 0
+	// Kept input is referenced by reader.
+	m.rest = nil
+	m.found = false
+	m.active = false
+}
@@ -0,0 +1,127 @@
+package jx
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_StreamIter(t *testing.T) {
+	type record struct {
+		Index  int
+		Offset int
+		Raw    string
+	}
+	collect := func(t *testing.T, d *Decoder) (records []record, failed []int) {
+		iter := d.StreamIter()
+		for iter.Next() {
+			raw, err := d.Raw()
+			if err != nil {
+				failed = append(failed, iter.Index())
+				require.NoError(t, iter.Recover())
+				continue
+			}
+			records = append(records, record{
+				Index:  iter.Index(),
+				Offset: iter.Offset(),
+				Raw:    raw.String(),
+			})
+		}
+		require.NoError(t, iter.Err())
+		require.False(t, iter.Next())
+		return records, failed
+	}
+	t.Run("NDJSON", testBufferReader("{\"a\":1}\n[1, 2]\n\n  \"s\"\n", func(t *testing.T, d *Decoder) {
+		records, failed := collect(t, d)
+		require.Empty(t, failed)
+		require.Equal(t, []record{
+			{0, 0, `{"a":1}`},
+			{1, 8, `[1, 2]`},
+			{2, 18, `"s"`},
+		}, records)
+	}))
+	t.Run("Concatenated", testBufferReader(`{"a":1}[]"s"1 true null`, func(t *testing.T, d *Decoder) {
+		records, failed := collect(t, d)
+		require.Empty(t, failed)
+		require.Equal(t, []record{
+			{0, 0, `{"a":1}`},
+			{1, 7, `[]`},
+			{2, 9, `"s"`},
+			{3, 12, `1`},
+			{4, 14, `true`},
+			{5, 19, `null`},
+		}, records)
+	}))
+	t.Run("Empty", testBufferReader(" \n\t", func(t *testing.T, d *Decoder) {
+		records, failed := collect(t, d)
+		require.Empty(t, failed)
+		require.Empty(t, records)
+	}))
+	t.Run("Recover", testBufferReader("{\"a\":1}\n{\"a\": [[[bad]]], \"b\": 2}\n[\"bad\" 1]\n2\n{\"c\":", func(t *testing.T, d *Decoder) {
+		records, failed := collect(t, d)
+		require.Equal(t, []int{1, 2, 4}, failed)
+		require.Equal(t, []record{
+			{0, 0, `{"a":1}`},
+			{3, 43, `2`},
+		}, records)
+		require.Zero(t, d.depth)
+	}))
+	t.Run("RecoverNextLine", testBufferReader("{\"a\":1\n{\"b\":2}\n{\"c\":3}\n", func(t *testing.T, d *Decoder) {
+		records, failed := collect(t, d)
+		require.Equal(t, []int{0}, failed)
+		require.Equal(t, []record{
+			{1, 7, `{"b":2}`},
+			{2, 15, `{"c":3}`},
+		}, records)
+	}))
+	t.Run("RecoverMultiline", testBufferReader("[1,\n2,\n{\"a\" 3}]\n[4]\n", func(t *testing.T, d *Decoder) {
+		// Lines after the first newline of failed value are decoded again.
+		records, failed := collect(t, d)
+		require.Equal(t, []int{0, 2, 3}, failed)
+		require.Equal(t, []record{
+			{1, 4, `2`},
+			{4, 16, `[4]`},
+		}, records)
+	}))
+	t.Run("RecoverBounded", func(t *testing.T) {
+		lines := strings.Repeat("1,\n", 200000)
+		r := &markReader{r: strings.NewReader("[" + lines + "1]\n[" + lines + "x]\n[4]\n")}
+		d := Decode(r, 0)
+		r.d = d
+
+		var (
+			iter   = d.StreamIter()
+			values []int
+			failed []int
+		)
+		for iter.Next() {
+			if err := d.Skip(); err != nil {
+				failed = append(failed, iter.Index())
+				require.NoError(t, iter.Recover())
+				continue
+			}
+			values = append(values, iter.Index())
+		}
+		require.NoError(t, iter.Err())
+		require.Equal(t, []int{0, 2}, values)
+		require.Equal(t, []int{1}, failed)
+		require.LessOrEqual(t, r.max, 2*lineMarkLimit)
+		require.Nil(t, d.mark.rest)
+	})
+}
+
+// markReader records maximum capacity of input kept for StreamIter.Recover.
+type markReader struct {
+	r   io.Reader
+	d   *Decoder
+	max int
+}
+
+func (m *markReader) Read(p []byte) (int, error) {
+	if c := cap(m.d.mark.rest); c > m.max {
+		m.max = c
+	}
+	return m.r.Read(p)
+}
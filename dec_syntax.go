@@ -0,0 +1,119 @@
+package jx
+
+import (
+	"bytes"
+	"io"
+)
+
+// Syntax is a json dialect accepted by Decoder.
+type Syntax byte
+
+const (
+	// SyntaxStrict accepts only RFC 8259 json.
+	//
+	// This is default syntax.
+	SyntaxStrict Syntax = iota
+	// SyntaxRelaxed additionally treats "//" and "/* */" comments as
+	// whitespace and allows trailing comma before "]" or "}".
+	SyntaxRelaxed
+	// SyntaxJSON5 accepts JSON5, which is SyntaxRelaxed plus unquoted
+	// identifier keys, single-quoted strings, additional escapes, hexadecimal
+	// numbers, leading and trailing decimal points, explicit plus sign,
+	// Infinity and NaN. Vertical tab and form feed are whitespace.
+	//
+	// Raw re-encodes value as strict json, Num returns number as json number.
+	SyntaxJSON5
+)
+
+// SetSyntax sets json dialect accepted by Decoder.
+func (d *Decoder) SetSyntax(s Syntax) {
+	d.opts.syntax = s
+}
+
+// relaxed reports whether comments and trailing commas are allowed.
+func (d *Decoder) relaxed() bool {
+	return d.opts.syntax >= SyntaxRelaxed
+}
+
+// commentStart reports whether c is start of comment, which ends number
+// or literal in relaxed syntax.
+func (d *Decoder) commentStart(c byte) bool {
+	return c == '/' && d.relaxed()
+}
+
+// skipComment skips comment.
+//
+// Assumes first slash was consumed.
+func (d *Decoder) skipComment() error {
+	offset := d.offset() - 1
+	c, err := d.byte()
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '/':
+		// Line comment, ends with newline or EOF.
+		return d.skipLine()
+	case '*':
+		// Block comment, ends with "*/".
+		star := false
+		for {
+			for i, c := range d.buf[d.head:d.tail] {
+				if star && c == '/' {
+					d.head += i + 1
+					return nil
+				}
+				star = c == '*'
+			}
+			d.head = d.tail
+			if err := d.read(); err != nil {
+				if err == io.EOF {
+					return io.ErrUnexpectedEOF
+				}
+				return err
+			}
+		}
+	default:
+		return d.badToken('/', offset)
+	}
+}
+
+// skipLine skips input up to and including next newline or EOF.
+func (d *Decoder) skipLine() error {
+	for {
+		buf := d.buf[d.head:d.tail]
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			d.head += i + 1
+			return nil
+		}
+		d.head = d.tail
+		if err := d.read(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// trailingComma reports whether comma is followed by end token.
+//
+// Consumes end token if so. Always false for strict syntax.
+func (d *Decoder) trailingComma(end byte) (bool, error) {
+	if d.opts.syntax == SyntaxStrict {
+		return false, nil
+	}
+	return d.trailingCommaSlow(end)
+}
+
+func (d *Decoder) trailingCommaSlow(end byte) (bool, error) {
+	c, err := d.more()
+	if err != nil {
+		return false, err
+	}
+	if c == end {
+		return true, nil
+	}
+	d.unread()
+	return false, nil
+}
@@ -0,0 +1,255 @@
+package jx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func iterValue(d *Decoder) error {
+	switch d.Next() {
+	case Array:
+		iter, err := d.ArrIter()
+		if err != nil {
+			return err
+		}
+		for iter.Next() {
+			if err := iterValue(d); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	case Object:
+		iter, err := d.ObjIter()
+		if err != nil {
+			return err
+		}
+		for iter.Next() {
+			if err := iterValue(d); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	default:
+		return crawlValue(d)
+	}
+}
+
+func TestDecoder_SetSyntax(t *testing.T) {
+	decoders := []struct {
+		Name   string
+		Decode func(d *Decoder) error
+	}{
+		{"Validate", (*Decoder).Validate},
+		{"Skip", (*Decoder).Skip},
+		{"Raw", decoderOnlyError((*Decoder).Raw)},
+		{"Crawl", crawlValue},
+		{"Iter", iterValue},
+		{"Capture", func(d *Decoder) error {
+			if err := d.Capture(crawlValue); err != nil {
+				return err
+			}
+			return d.Validate()
+		}},
+	}
+	t.Run("Relaxed", func(t *testing.T) {
+		for _, input := range []string{
+			`// comment
+{"a": 1}`,
+			`{"a": 1} // comment`,
+			`{"a": 1} /* comment */`,
+			`/**/1`,
+			`1/**/`,
+			`1// comment`,
+			`-1.5e10/* comment */`,
+			`/* multi
+			line ** comment */ [1, 2]`,
+			`[1, 2,]`,
+			`[1, 2 , ]`,
+			`[[],[],]`,
+			`{"a": 1,}`,
+			`{"a": {"b": [1,],},}`,
+			`{
+	// Comment before key.
+	"a": /* before value */ 1, // after value
+	"b" /* before colon */ : [
+		1, /* between elements */ 2, // trailing comma
+	],
+	"c": "/* not a comment */", /* before end */
+}`,
+			`/*a*//*b*/[/*c*/"//"/*d*/,/*e*/]/*f*/`,
+		} {
+			for _, dec := range decoders {
+				dec := dec
+				t.Run(dec.Name, testBufferReader(input, func(t *testing.T, d *Decoder) {
+					d.SetSyntax(SyntaxRelaxed)
+					require.NoError(t, dec.Decode(d))
+				}))
+				t.Run("Strict"+dec.Name, testBufferReader(input, func(t *testing.T, d *Decoder) {
+					require.Error(t, func() error {
+						if err := dec.Decode(d); err != nil {
+							return err
+						}
+						return d.Validate()
+					}())
+				}))
+			}
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{
+			`[1,,]`,
+			`[,]`,
+			`{,}`,
+			`{"a":1,,}`,
+			`/ comment`,
+			`/* unterminated`,
+			`[1 /* unterminated ]`,
+			`[1 / 2]`,
+			`{"a" / "b"}`,
+			`// only comment`,
+			`[1,]]`,
+		} {
+			for _, dec := range decoders {
+				dec := dec
+				t.Run(dec.Name, testBufferReader(input, func(t *testing.T, d *Decoder) {
+					d.SetSyntax(SyntaxRelaxed)
+					require.Error(t, func() error {
+						if err := dec.Decode(d); err != nil {
+							return err
+						}
+						return d.Validate()
+					}())
+				}))
+			}
+		}
+	})
+	t.Run("Number", func(t *testing.T) {
+		readers := []struct {
+			Name string
+			Read func(d *Decoder) (float64, error)
+		}{
+			{"Float64", (*Decoder).Float64},
+			{"Float32", func(d *Decoder) (float64, error) {
+				v, err := d.Float32()
+				return float64(v), err
+			}},
+			{"Int", func(d *Decoder) (float64, error) {
+				v, err := d.Int()
+				return float64(v), err
+			}},
+			{"Int32", func(d *Decoder) (float64, error) {
+				v, err := d.Int32()
+				return float64(v), err
+			}},
+			{"Num", func(d *Decoder) (float64, error) {
+				v, err := d.Num()
+				if err != nil {
+					return 0, err
+				}
+				return v.Float64()
+			}},
+			{"Skip", func(d *Decoder) (float64, error) {
+				return 0, d.Skip()
+			}},
+		}
+		for _, tt := range []struct {
+			Input string
+			Value float64
+			Float bool
+		}{
+			{"0", 0, false},
+			{"1", 1, false},
+			{"-12", -12, false},
+			{"12345678", 12345678, false},
+			{"1.5", 1.5, true},
+			{"-0.25", -0.25, true},
+			{"1e2", 100, true},
+		} {
+			for _, r := range readers {
+				if tt.Float && r.Name != "Float64" && r.Name != "Float32" && r.Name != "Num" && r.Name != "Skip" {
+					continue
+				}
+				r := r
+				tt := tt
+				t.Run(r.Name, func(t *testing.T) {
+					// Slash ends number only if comments are allowed.
+					strict := tt.Input + "/"
+					_, err := r.Read(DecodeStr(strict))
+					require.Error(t, err, strict)
+
+					for _, input := range []string{
+						"[" + tt.Input + "/**/]",
+						"[" + tt.Input + "// comment\n]",
+					} {
+						d := DecodeStr(input)
+						d.SetSyntax(SyntaxRelaxed)
+						require.NoError(t, d.Arr(func(d *Decoder) error {
+							v, err := r.Read(d)
+							if err == nil && r.Name != "Skip" {
+								require.Equal(t, tt.Value, v, input)
+							}
+							return err
+						}), input)
+					}
+				})
+			}
+		}
+	})
+	t.Run("Values", func(t *testing.T) {
+		d := DecodeStr(`{
+	"n": 10, // number
+	"s": "str", /* string */
+	"a": [true, null,],
+}`)
+		d.SetSyntax(SyntaxRelaxed)
+		var (
+			n int
+			s string
+			a []Type
+		)
+		require.NoError(t, d.Obj(func(d *Decoder, key string) (err error) {
+			switch key {
+			case "n":
+				n, err = d.Int()
+			case "s":
+				s, err = d.Str()
+			case "a":
+				err = d.Arr(func(d *Decoder) error {
+					a = append(a, d.Next())
+					return d.Skip()
+				})
+			}
+			return err
+		}))
+		require.Equal(t, 10, n)
+		require.Equal(t, "str", s)
+		require.Equal(t, []Type{Bool, Null}, a)
+	})
+	t.Run("Elem", func(t *testing.T) {
+		d := DecodeStr(`[1, /* two */ 2, ]`)
+		d.SetSyntax(SyntaxRelaxed)
+		var count int
+		for {
+			ok, err := d.Elem()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			require.NoError(t, d.Skip())
+			count++
+		}
+		require.Equal(t, 2, count)
+	})
+	t.Run("Raw", func(t *testing.T) {
+		d := DecodeStr(`[1, [2, /* c */ 3,], 4]`)
+		d.SetSyntax(SyntaxRelaxed)
+		var raws []string
+		require.NoError(t, d.Arr(func(d *Decoder) error {
+			raw, err := d.Raw()
+			raws = append(raws, raw.String())
+			return err
+		}))
+		require.Equal(t, []string{"1", "[2, /* c */ 3,]", "4"}, raws)
+	})
+}
@@ -0,0 +1,267 @@
+package jx
+
+import (
+	"github.com/go-faster/errors"
+)
+
+// TokenKind is kind of Token.
+type TokenKind byte
+
+const (
+	// TokenInvalid is zero value of TokenKind.
+	TokenInvalid TokenKind = iota
+	// TokenObjStart is start of object, "{".
+	TokenObjStart
+	// TokenObjEnd is end of object, "}".
+	TokenObjEnd
+	// TokenArrStart is start of array, "[".
+	TokenArrStart
+	// TokenArrEnd is end of array, "]".
+	TokenArrEnd
+	// TokenKey is object key.
+	TokenKey
+	// TokenString is string value.
+	TokenString
+	// TokenNumber is number value.
+	TokenNumber
+	// TokenBool is true or false.
+	TokenBool
+	// TokenNull is null.
+	TokenNull
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenInvalid:
+		return "invalid"
+	case TokenObjStart:
+		return "object start"
+	case TokenObjEnd:
+		return "object end"
+	case TokenArrStart:
+		return "array start"
+	case TokenArrEnd:
+		return "array end"
+	case TokenKey:
+		return "key"
+	case TokenString:
+		return "string"
+	case TokenNumber:
+		return "number"
+	case TokenBool:
+		return "bool"
+	case TokenNull:
+		return "null"
+	default:
+		return "unknown"
+	}
+}
+
+// Token is json token returned by Decoder.Token.
+type Token struct {
+	Kind TokenKind
+	// Value is unescaped key or string, or json number.
+	//
+	// Valid only until next call to any Decoder method.
+	Value []byte
+	// Bool is value of TokenBool.
+	Bool bool
+}
+
+// tokenFrame is state of array or object opened by Token.
+type tokenFrame struct {
+	obj   bool
+	state tokenState
+}
+
+type tokenState byte
+
+const (
+	tokenStart tokenState = iota // after "[" or "{"
+	tokenComma                   // after ","
+	tokenColon                   // after key and ":"
+	tokenValue                   // after value
+)
+
+// Token reads next json token.
+//
+// Commas and colons are consumed implicitly. Returns io.EOF if there is no
+// more top-level values.
+//
+// Token can be mixed with other Decoder methods: value may be read by e.g.
+// Int or Skip instead of Token, and nested value may be read by Token
+// inside Obj or Arr callback. Commas are consumed only by Token, so values
+// of array or object opened by Token should be separated by Token calls.
+func (d *Decoder) Token() (Token, error) {
+	tok, err := d.token()
+	d.tokenOffset = d.offset()
+	return tok, err
+}
+
+func (d *Decoder) token() (Token, error) {
+	n := len(d.tokens)
+	if n == 0 {
+		c, err := d.next()
+		if err != nil {
+			return Token{}, err
+		}
+		return d.tokenValue(c)
+	}
+
+	f := &d.tokens[n-1]
+	if d.offset() != d.tokenOffset {
+		// Value was read by other method.
+		switch {
+		case !f.obj && f.state != tokenValue,
+			f.obj && f.state == tokenColon:
+			f.state = tokenValue
+		}
+	}
+	end := byte(']')
+	if f.obj {
+		end = '}'
+	}
+
+	c, err := d.more()
+	if err != nil {
+		return Token{}, err
+	}
+	switch f.state {
+	case tokenStart:
+		if c == end {
+			return d.tokenEnd()
+		}
+	case tokenValue:
+		switch c {
+		case end:
+			return d.tokenEnd()
+		case ',':
+			f.state = tokenComma
+			ok, err := d.trailingComma(end)
+			if err != nil {
+				return Token{}, err
+			}
+			if ok {
+				return d.tokenEnd()
+			}
+			if c, err = d.more(); err != nil {
+				return Token{}, err
+			}
+		default:
+			err := d.badToken(c, d.offset()-1)
+			return Token{}, errors.Wrapf(err, `"," or %q expected`, end)
+		}
+	}
+
+	if f.obj && f.state != tokenColon {
+		d.unread()
+		// Do not reference internal buffer for key if decoder is not
+		// buffered, reading colon may overwrite it.
+		k, err := d.objKey(value{buf: d.scratch[:0], raw: d.reader == nil})
+		if err != nil {
+			return Token{}, errors.Wrap(err, "field name")
+		}
+		if !k.raw {
+			d.scratch = k.buf[:0]
+		}
+		if err := d.consume(':'); err != nil {
+			return Token{}, errors.Wrap(err, `":" expected`)
+		}
+		f.state = tokenColon
+		return Token{Kind: TokenKey, Value: k.buf}, nil
+	}
+	// Frame may be reallocated by tokenValue.
+	f.state = tokenValue
+	return d.tokenValue(c)
+}
+
+// tokenEnd closes current array or object.
+func (d *Decoder) tokenEnd() (Token, error) {
+	n := len(d.tokens)
+	f := d.tokens[n-1]
+	d.tokens = d.tokens[:n-1]
+	if err := d.decDepth(); err != nil {
+		return Token{}, err
+	}
+	if f.obj {
+		return Token{Kind: TokenObjEnd}, nil
+	}
+	return Token{Kind: TokenArrEnd}, nil
+}
+
+// tokenValue reads value token starting with consumed c.
+func (d *Decoder) tokenValue(c byte) (Token, error) {
+	switch c {
+	case '{', '[':
+		if err := d.incDepth(); err != nil {
+			return Token{}, err
+		}
+		obj := c == '{'
+		d.tokens = append(d.tokens, tokenFrame{obj: obj})
+		if obj {
+			d.objStart()
+			return Token{Kind: TokenObjStart}, nil
+		}
+		return Token{Kind: TokenArrStart}, nil
+	}
+
+	d.unread()
+	typ := types[c]
+	if d.opts.syntax == SyntaxJSON5 {
+		typ = types5[c]
+	}
+	switch typ {
+	case String:
+		v, err := d.str(value{buf: d.scratch[:0], raw: true})
+		if err != nil {
+			return Token{}, errors.Wrap(err, "str")
+		}
+		if !v.raw {
+			d.scratch = v.buf[:0]
+		}
+		return Token{Kind: TokenString, Value: v.buf}, nil
+	case Number:
+		v, err := d.tokenNumber()
+		if err != nil {
+			return Token{}, errors.Wrap(err, "number")
+		}
+		return Token{Kind: TokenNumber, Value: v}, nil
+	case Bool:
+		v, err := d.Bool()
+		if err != nil {
+			return Token{}, err
+		}
+		return Token{Kind: TokenBool, Bool: v}, nil
+	case Null:
+		if err := d.Null(); err != nil {
+			return Token{}, err
+		}
+		return Token{Kind: TokenNull}, nil
+	default:
+		return Token{}, d.badToken(c, d.offset())
+	}
+}
+
+// tokenNumber reads json number.
+func (d *Decoder) tokenNumber() ([]byte, error) {
+	if d.reader == nil && d.opts.syntax != SyntaxJSON5 {
+		start := d.head
+		if err := d.skipNumber(); err != nil {
+			return nil, err
+		}
+		return d.buf[start:d.head], nil
+	}
+
+	v, err := d.numberAppend(d.scratch[:0])
+	if err != nil {
+		return nil, err
+	}
+	d.scratch = v[:0]
+	if d.opts.syntax != SyntaxJSON5 {
+		nd := Decoder{buf: v, tail: len(v)}
+		if err := nd.skipNumber(); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
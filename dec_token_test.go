@@ -0,0 +1,216 @@
+package jx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// tokenString formats tokens of json value for tests.
+func tokenString(d *Decoder) (string, error) {
+	var b strings.Builder
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return b.String(), nil
+		}
+		if err != nil {
+			return b.String(), err
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		switch tok.Kind {
+		case TokenObjStart:
+			b.WriteByte('{')
+		case TokenObjEnd:
+			b.WriteByte('}')
+		case TokenArrStart:
+			b.WriteByte('[')
+		case TokenArrEnd:
+			b.WriteByte(']')
+		case TokenKey:
+			fmt.Fprintf(&b, "%s:", tok.Value)
+		case TokenString:
+			fmt.Fprintf(&b, "%q", tok.Value)
+		case TokenNumber:
+			b.Write(tok.Value)
+		case TokenBool:
+			fmt.Fprint(&b, tok.Bool)
+		case TokenNull:
+			b.WriteString("null")
+		}
+	}
+}
+
+func TestDecoder_Token(t *testing.T) {
+	for i, tt := range []struct {
+		Input  string
+		Output string
+	}{
+		{`{}`, `{ }`},
+		{`[]`, `[ ]`},
+		{`{"a": 1, "b": [true, false, null], "c": {"d": "e\n"}}`, `{ a: 1 b: [ true false null ] c: { d: "e\n" } }`},
+		{`[[], {}, [[1.5e10]], -0]`, `[ [ ] { } [ [ 1.5e10 ] ] -0 ]`},
+		{`"A"`, `"A"`},
+		{`1 "a" [] {}`, `1 "a" [ ] { }`},
+		{`{"key\"": "value"}`, `{ key": "value" }`},
+		{``, ``},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+			s, err := tokenString(d)
+			require.NoError(t, err)
+			require.Equal(t, tt.Output, s)
+			require.Zero(t, d.depth)
+		}))
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{
+			`[1 2]`,
+			`[1,]`,
+			`[,1]`,
+			`{"a" 1}`,
+			`{"a": 1,}`,
+			`{1: 1}`,
+			`{"a": 1 "b": 2}`,
+			`{"a"}`,
+			`[1}`,
+			`{"a": 1]`,
+			`]`,
+			`[`,
+			`{"a":`,
+			`[01]`,
+			`[1.]`,
+			`[tru]`,
+		} {
+			input := input
+			t.Run(input, testBufferReader(input, func(t *testing.T, d *Decoder) {
+				_, err := tokenString(d)
+				require.Error(t, err)
+			}))
+		}
+	})
+	t.Run("Relaxed", testBufferReader(`[1, /* two */ 2,]`, func(t *testing.T, d *Decoder) {
+		d.SetSyntax(SyntaxRelaxed)
+		s, err := tokenString(d)
+		require.NoError(t, err)
+		require.Equal(t, `[ 1 2 ]`, s)
+	}))
+	t.Run("JSON5", testBufferReader(`{a: 'b', c: [0x10, .5,],}`, func(t *testing.T, d *Decoder) {
+		d.SetSyntax(SyntaxJSON5)
+		s, err := tokenString(d)
+		require.NoError(t, err)
+		require.Equal(t, `{ a: "b" c: [ 16 0.5 ] }`, s)
+	}))
+	t.Run("Depth", func(t *testing.T) {
+		d := DecodeStr(`[[[]]]`)
+		d.SetMaxDepth(2)
+		_, err := tokenString(d)
+		require.ErrorIs(t, err, ErrMaxDepth)
+	})
+	t.Run("DuplicateKeys", func(t *testing.T) {
+		d := DecodeStr(`{"a": 1, "a": 2}`)
+		d.SetRejectDuplicateKeys(true)
+		_, err := tokenString(d)
+		var dupErr *DuplicateKeyError
+		require.ErrorAs(t, err, &dupErr)
+	})
+	t.Run("Mixed", testBufferReader(`{"a": 1, "b": [1, {"c": 2}, {}, 3], "d": "e", "f": null}`, func(t *testing.T, d *Decoder) {
+		a := require.New(t)
+		next := func(kind TokenKind, value string) {
+			tok, err := d.Token()
+			a.NoError(err)
+			a.Equal(kind, tok.Kind)
+			a.Equal(value, string(tok.Value))
+		}
+
+		next(TokenObjStart, "")
+		next(TokenKey, "a")
+		v, err := d.Int()
+		a.NoError(err)
+		a.Equal(1, v)
+
+		next(TokenKey, "b")
+		next(TokenArrStart, "")
+		// Read elements by other methods.
+		a.NoError(d.Skip())
+		next(TokenObjStart, "")
+		next(TokenKey, "c")
+		a.NoError(d.Skip())
+		next(TokenObjEnd, "")
+		next(TokenObjStart, "")
+		next(TokenObjEnd, "")
+		next(TokenNumber, "3")
+		next(TokenArrEnd, "")
+
+		next(TokenKey, "d")
+		s, err := d.Str()
+		a.NoError(err)
+		a.Equal("e", s)
+
+		// Token inside callback.
+		a.NoError(d.Capture(func(d *Decoder) error {
+			next(TokenKey, "f")
+			next(TokenNull, "")
+			next(TokenObjEnd, "")
+			return nil
+		}))
+		next(TokenKey, "f")
+		next(TokenNull, "")
+		next(TokenObjEnd, "")
+		_, err = d.Token()
+		a.ErrorIs(err, io.EOF)
+		a.Zero(d.depth)
+	}))
+	t.Run("Callback", func(t *testing.T) {
+		d := DecodeStr(`[{"a": [1, 2]}, {"a": []}]`)
+		var got []string
+		require.NoError(t, d.Arr(func(d *Decoder) error {
+			for depth := 0; ; {
+				tok, err := d.Token()
+				if err != nil {
+					return err
+				}
+				got = append(got, tok.Kind.String())
+				switch tok.Kind {
+				case TokenObjStart, TokenArrStart:
+					depth++
+				case TokenObjEnd, TokenArrEnd:
+					depth--
+				}
+				if depth == 0 {
+					return nil
+				}
+			}
+		}))
+		require.Equal(t, []string{
+			"object start", "key", "array start", "number", "number", "array end", "object end",
+			"object start", "key", "array start", "array end", "object end",
+		}, got)
+	})
+}
+
+func TestDecoder_Token_zeroAlloc(t *testing.T) {
+	data := []byte(`{"a": [1, "b\nc", true, null], "d\n": {"e": -1.5}}`)
+	d := DecodeBytes(data)
+	// Warm up scratch buffer.
+	_, err := tokenString(d)
+	require.NoError(t, err)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		d.ResetBytes(data)
+		for {
+			if _, err := d.Token(); err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				return
+			}
+		}
+	})
+	require.Zero(t, allocs)
+}
@@ -0,0 +1,128 @@
+package jx
+
+import (
+	"unicode/utf8"
+
+	"github.com/go-faster/errors"
+)
+
+// UTF8Mode is a policy for invalid UTF-8 in strings.
+type UTF8Mode byte
+
+const (
+	// UTF8Unchecked passes string bytes through without validation.
+	//
+	// This is default mode.
+	UTF8Unchecked UTF8Mode = iota
+	// UTF8Strict rejects strings with invalid UTF-8 or unpaired surrogate
+	// escapes, as required by RFC 8259, with error matching ErrInvalidUTF8.
+	UTF8Strict
+	// UTF8Replace replaces invalid UTF-8 and unpaired surrogate escapes
+	// with U+FFFD, like encoding/json does.
+	UTF8Replace
+)
+
+// ErrInvalidUTF8 means that string contains invalid UTF-8 or unpaired
+// surrogate escape.
+//
+// Returned only in UTF8Strict mode.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// SetUTF8Mode sets policy for invalid UTF-8 in strings.
+//
+// Affects Str, StrBytes, StrAppend, object keys, Skip and Validate.
+// Skip and Validate accept invalid strings in UTF8Replace mode.
+func (d *Decoder) SetUTF8Mode(mode UTF8Mode) {
+	d.opts.utf8 = mode
+}
+
+// checkUTF8 checks v.buf[from:] according to UTF-8 mode.
+//
+// Checked bytes must be raw input bytes, ending right before end offset.
+func (d *Decoder) checkUTF8(v value, from, end int) (value, error) {
+	if d.opts.utf8 == UTF8Unchecked {
+		return v, nil
+	}
+	s := v.buf[from:]
+	if utf8.Valid(s) {
+		return v, nil
+	}
+
+	if d.opts.utf8 == UTF8Strict {
+		idx := 0
+		for idx < len(s) {
+			r, size := utf8.DecodeRune(s[idx:])
+			if r == utf8.RuneError && size == 1 {
+				break
+			}
+			idx += size
+		}
+		offset := end - len(s) + idx
+		return v, classify(ErrInvalidUTF8, d.badToken(s[idx], offset))
+	}
+
+	// Do not overwrite s: it may reference input buffer.
+	buf := make([]byte, 0, len(v.buf)+len(s)/2)
+	buf = append(buf, v.buf[:from]...)
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, "\uFFFD"...)
+		} else {
+			buf = append(buf, s[:size]...)
+		}
+		s = s[size:]
+	}
+	return value{buf: buf}, nil
+}
+
+// skipStrStrict reads one JSON string, validating UTF-8.
+//
+// Assumes first quote was consumed.
+func (d *Decoder) skipStrStrict() error {
+	// Fast path for string without escapes, which is within buffer.
+	buf := d.buf[d.head:d.tail]
+	i := 0
+scan:
+	for i < len(buf) {
+		i += scanStrASCII(buf[i:])
+		if i == len(buf) {
+			break
+		}
+		switch c := buf[i]; {
+		case c == '"':
+			d.head += i + 1
+			return nil
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRune(buf[i:])
+			if r == utf8.RuneError && size == 1 {
+				if !utf8.FullRune(buf[i:]) {
+					// Rune continues in next buffer.
+					break scan
+				}
+				return classify(ErrInvalidUTF8, d.badToken(c, d.offset()+i))
+			}
+			i += size
+		default:
+			// Escape or control character.
+			break scan
+		}
+	}
+	d.head += i
+
+	v, err := d.strBody(value{buf: d.scratch[:0]})
+	if err != nil {
+		return err
+	}
+	d.scratch = v.buf[:0]
+	return nil
+}
+
+// loneSurrogate appends unpaired surrogate escape at offset as U+FFFD.
+func (d *Decoder) loneSurrogate(v value, r rune, offset int) (value, error) {
+	if d.opts.utf8 == UTF8Strict {
+		err := errors.Errorf("unpaired surrogate %U at %d", r, offset)
+		return v, classify(ErrInvalidUTF8, err)
+	}
+	return v.rune(r), nil
+}
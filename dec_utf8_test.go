@@ -0,0 +1,149 @@
+package jx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_SetUTF8Mode(t *testing.T) {
+	long := strings.Repeat("ab", 300)
+	for _, tt := range []struct {
+		Name  string
+		Input string
+		Valid bool
+	}{
+		{"ASCII", `"hello"`, true},
+		{"Multibyte", `"привет, 世界 🌍"`, true},
+		{"Escaped", `"\u043f\u0440\ud83c\udf0d"`, true},
+		{"EscapedMixed", `"при\n\u0432ет"`, true},
+		{"Long", `"` + long + "привет" + long + `"`, true},
+
+		{"Invalid", "\"a\xffb\"", false},
+		{"Truncated", "\"a\xd0\"", false},
+		{"Overlong", "\"\xc0\xaf\"", false},
+		{"EncodedSurrogate", "\"\xed\xa0\x80\"", false},
+		{"AfterEscape", "\"\\n\xff\"", false},
+		{"BeforeEscape", "\"\xff\\n\"", false},
+		{"Long", `"` + long + "\xff" + long + `"`, false},
+		{"LoneHigh", `"\ud83c"`, false},
+		{"LoneHighChar", `"\ud83cx"`, false},
+		{"LoneHighEscape", `"\ud83c\n"`, false},
+		{"LoneLow", `"\udf0d"`, false},
+		{"HighHigh", `"\ud83c\ud83c"`, false},
+		{"HighChar", `"\ud83c\u0041"`, false},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			var expected string
+			require.NoError(t, json.Unmarshal([]byte(tt.Input), &expected))
+
+			t.Run("Strict", func(t *testing.T) {
+				check := func(t *testing.T, input string, err error) {
+					if tt.Valid {
+						require.NoError(t, err)
+						return
+					}
+					require.ErrorIs(t, err, ErrInvalidUTF8)
+					var se *SyntaxError
+					if errors.As(err, &se) {
+						require.Equal(t, input[se.Offset], se.Token)
+						require.GreaterOrEqual(t, se.Token, byte(0x80))
+					}
+				}
+				t.Run("Str", testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Strict)
+					s, err := d.Str()
+					check(t, tt.Input, err)
+					if tt.Valid {
+						require.Equal(t, expected, s)
+					}
+				}))
+				t.Run("StrAppend", testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Strict)
+					_, err := d.StrAppend([]byte("prefix"))
+					check(t, tt.Input, err)
+				}))
+				t.Run("Validate", testBufferReader(`[`+tt.Input+`]`, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Strict)
+					check(t, `[`+tt.Input+`]`, d.Validate())
+				}))
+				t.Run("Key", testBufferReader(`{`+tt.Input+`:1}`, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Strict)
+					check(t, `{`+tt.Input+`:1}`, d.ObjBytes(func(d *Decoder, key []byte) error {
+						return d.Skip()
+					}))
+				}))
+				t.Run("SkipKey", testBufferReader(`{`+tt.Input+`:1}`, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Strict)
+					check(t, `{`+tt.Input+`:1}`, d.Skip())
+				}))
+			})
+			t.Run("Replace", func(t *testing.T) {
+				t.Run("Str", testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Replace)
+					s, err := d.Str()
+					require.NoError(t, err)
+					require.Equal(t, expected, s)
+				}))
+				t.Run("StrAppend", testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Replace)
+					s, err := d.StrAppend([]byte("prefix"))
+					require.NoError(t, err)
+					require.Equal(t, "prefix"+expected, string(s))
+				}))
+				t.Run("Validate", testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+					d.SetUTF8Mode(UTF8Replace)
+					require.NoError(t, d.Validate())
+				}))
+			})
+			t.Run("Unchecked", testBufferReader(tt.Input, func(t *testing.T, d *Decoder) {
+				require.NoError(t, d.Validate())
+			}))
+		})
+	}
+	t.Run("ReplaceKeepsInput", func(t *testing.T) {
+		input := []byte("\"a\xffb\"")
+		d := DecodeBytes(input)
+		d.SetUTF8Mode(UTF8Replace)
+		s, err := d.StrBytes()
+		require.NoError(t, err)
+		require.Equal(t, "a\uFFFDb", string(s))
+		require.Equal(t, "\"a\xffb\"", string(input))
+	})
+}
+
+func BenchmarkDecoder_SetUTF8Mode(b *testing.B) {
+	for _, file := range []string{"twitter.json", "citm_catalog.json"} {
+		data, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, mode := range []struct {
+			Name string
+			Mode UTF8Mode
+		}{
+			{"Unchecked", UTF8Unchecked},
+			{"Strict", UTF8Strict},
+		} {
+			mode := mode
+			b.Run(file+"/"+mode.Name, func(b *testing.B) {
+				var d Decoder
+				d.SetUTF8Mode(mode.Mode)
+				b.ReportAllocs()
+				b.SetBytes(int64(len(data)))
+				for i := 0; i < b.N; i++ {
+					d.ResetBytes(data)
+					if err := d.Validate(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
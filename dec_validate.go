@@ -8,15 +8,29 @@ import (
 
 // Validate consumes all input, validating that input is a json object
 // without any trialing data.
+//
+// Returns error matching ErrTrailingData if there is any data after value.
 func (d *Decoder) Validate() error {
 	// First encountered value skip should consume all buffer.
 	if err := d.Skip(); err != nil {
 		return errors.Wrap(err, "consume")
 	}
 	// Check for any trialing json.
-	if err := d.Skip(); err != io.EOF {
+	return d.end()
+}
+
+// end checks that there is no data left except whitespace.
+//
+// Returns error matching ErrTrailingData if there is any data.
+func (d *Decoder) end() error {
+	c, err := d.next()
+	switch err {
+	case io.EOF:
+		return nil
+	case nil:
+		err := classify(ErrTrailingData, d.badToken(c, d.offset()-1))
 		return errors.Wrap(err, "unexpected trialing data")
+	default:
+		return err
 	}
-
-	return nil
 }
@@ -23,6 +23,10 @@ type Encoder struct {
 	//
 	// See https://yourbasic.org/algorithms/your-basic-int/#simple-sets
 	first []bool
+
+	checked bool       // track structural errors
+	scopes  []encScope // state of objects and arrays in checked mode
+	err     error      // first structural error
 }
 
 // Write implements io.Writer.
@@ -51,12 +55,14 @@ func (e Encoder) String() string {
 func (e *Encoder) Reset() {
 	e.w.Reset()
 	e.first = e.first[:0]
+	e.resetCheck()
 }
 
 // ResetWriter resets underlying buffer and sets output writer.
 func (e *Encoder) ResetWriter(out io.Writer) {
 	e.w.ResetWriter(out)
 	e.first = e.first[:0]
+	e.resetCheck()
 }
 
 // Grow grows the underlying buffer
@@ -103,7 +109,10 @@ func (e *Encoder) Bool(v bool) bool {
 //
 // Use Obj as convenience helper for writing objects.
 func (e *Encoder) ObjStart() (fail bool) {
-	fail = e.comma() || e.w.ObjStart()
+	if e.checked {
+		e.checkStart(true)
+	}
+	fail = e.sep() || e.w.ObjStart()
 	e.begin()
 	return fail || e.writeIndent()
 }
@@ -114,7 +123,10 @@ func (e *Encoder) ObjStart() (fail bool) {
 //
 // Use Field as convenience helper for encoding fields.
 func (e *Encoder) FieldStart(field string) (fail bool) {
-	fail = e.comma() || e.w.FieldStart(field)
+	if e.checked {
+		e.checkField()
+	}
+	fail = e.sep() || e.w.FieldStart(field)
 	if e.indent > 0 {
 		fail = fail || e.byte(' ')
 	}
@@ -129,15 +141,32 @@ func (e *Encoder) FieldStart(field string) (fail bool) {
 // Has ~5ns overhead over FieldStart.
 func (e *Encoder) Field(name string, f func(e *Encoder)) (fail bool) {
 	fail = e.FieldStart(name)
-	// TODO(tdakkota): return bool from f?
 	f(e)
 	return fail
 }
 
+// FieldErr encodes field start and then invokes callback, like Field, but
+// callback can fail.
+//
+// If callback fails without writing field value, null is written to keep
+// output valid. Returns callback error or write error in streaming mode, in
+// which case callback is not invoked.
+func (e *Encoder) FieldErr(name string, f func(e *Encoder) error) error {
+	err := e.callErr(e.FieldStart(name), f)
+	if err != nil && len(e.first) > 0 && e.first[e.current()] {
+		// Field value is not written.
+		e.Null()
+	}
+	return err
+}
+
 // ObjEnd writes end of object token, performing indentation if needed.
 //
 // Use Obj as convenience helper for writing objects.
 func (e *Encoder) ObjEnd() bool {
+	if e.checked {
+		e.checkEnd(true)
+	}
 	e.end()
 	return e.writeIndent() || e.w.ObjEnd()
 }
@@ -157,16 +186,35 @@ func (e *Encoder) Obj(f func(e *Encoder)) (fail bool) {
 		return e.ObjEmpty()
 	}
 	fail = e.ObjStart()
-	// TODO(tdakkota): return bool from f?
 	f(e)
 	return fail || e.ObjEnd()
 }
 
+// ObjErr writes start of object, invokes callback and writes end of object,
+// like Obj, but callback can fail.
+//
+// Object is ended even if callback fails, so nested ObjErr, ArrErr and
+// FieldErr calls close all containers. Returns callback error or write
+// error in streaming mode, in which case callback is not invoked.
+func (e *Encoder) ObjErr(f func(e *Encoder) error) error {
+	if f == nil {
+		return e.writeErr(e.ObjEmpty())
+	}
+	err := e.callErr(e.ObjStart(), f)
+	if e.ObjEnd() && err == nil {
+		err = e.writeErr(true)
+	}
+	return err
+}
+
 // ArrStart writes start of array, performing indentation if needed.
 //
 // Use Arr as convenience helper for writing arrays.
 func (e *Encoder) ArrStart() (fail bool) {
-	fail = e.comma() || e.w.ArrStart()
+	if e.checked {
+		e.checkStart(false)
+	}
+	fail = e.sep() || e.w.ArrStart()
 	e.begin()
 	return fail || e.writeIndent()
 }
@@ -182,6 +230,9 @@ func (e *Encoder) ArrEmpty() bool {
 //
 // Use Arr as convenience helper for writing arrays.
 func (e *Encoder) ArrEnd() bool {
+	if e.checked {
+		e.checkEnd(false)
+	}
 	e.end()
 	return e.writeIndent() ||
 		e.w.ArrEnd()
@@ -195,11 +246,43 @@ func (e *Encoder) Arr(f func(e *Encoder)) (fail bool) {
 		return e.ArrEmpty()
 	}
 	fail = e.ArrStart()
-	// TODO(tdakkota): return bool from f?
 	f(e)
 	return fail || e.ArrEnd()
 }
 
+// ArrErr writes start of array, invokes callback and writes end of array,
+// like Arr, but callback can fail.
+//
+// See ObjErr for details.
+func (e *Encoder) ArrErr(f func(e *Encoder) error) error {
+	if f == nil {
+		return e.writeErr(e.ArrEmpty())
+	}
+	err := e.callErr(e.ArrStart(), f)
+	if e.ArrEnd() && err == nil {
+		err = e.writeErr(true)
+	}
+	return err
+}
+
+// callErr invokes callback if writing did not fail.
+func (e *Encoder) callErr(fail bool, f func(e *Encoder) error) error {
+	if fail {
+		return e.writeErr(true)
+	}
+	return f(e)
+}
+
+// writeErr returns write error if fail is true.
+//
+// Writing can fail only in streaming mode.
+func (e *Encoder) writeErr(fail bool) error {
+	if !fail || e.w.stream == nil {
+		return nil
+	}
+	return e.w.stream.writeErr
+}
+
 func (e *Encoder) writeIndent() (fail bool) {
 	if e.indent == 0 {
 		return false
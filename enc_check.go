@@ -0,0 +1,127 @@
+package jx
+
+import "fmt"
+
+// EncoderError describes structural misuse of Encoder, like ObjEnd without
+// ObjStart or FieldStart inside array.
+//
+// See Encoder.SetChecked.
+type EncoderError struct {
+	Op     string // misused method, "value" for methods writing values
+	Depth  int    // nesting depth at misuse
+	Reason string
+}
+
+func (e *EncoderError) Error() string {
+	return fmt.Sprintf("jx: %s at depth %d: %s", e.Op, e.Depth, e.Reason)
+}
+
+// encScope is state of object or array being written in checked mode.
+type encScope struct {
+	obj   bool // object or array
+	field bool // field name is written, value is expected
+}
+
+// SetChecked sets whether Encoder should track object, array and field
+// state, recording first structural misuse, which is returned by Err.
+//
+// Invalid json is written as is, so checking does not change output.
+// Multiple top-level values are allowed, like in SeqRecord.
+func (e *Encoder) SetChecked(checked bool) {
+	e.checked = checked
+	e.resetCheck()
+}
+
+// Err returns first structural error recorded in checked mode, if any,
+// or error of underlying Writer, see Writer.Err.
+//
+// Unclosed objects and arrays are not reported, see Complete.
+func (e *Encoder) Err() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Err()
+}
+
+// Complete reports whether all objects and arrays are closed in checked
+// mode and no error occurred.
+func (e *Encoder) Complete() bool {
+	return e.Err() == nil && len(e.scopes) == 0
+}
+
+func (e *Encoder) resetCheck() {
+	e.scopes = e.scopes[:0]
+	e.err = nil
+}
+
+func (e *Encoder) checkFail(op, reason string) {
+	if e.err != nil {
+		return
+	}
+	e.err = &EncoderError{
+		Op:     op,
+		Depth:  len(e.scopes),
+		Reason: reason,
+	}
+}
+
+// checkValue checks that value can be written.
+func (e *Encoder) checkValue() {
+	if len(e.scopes) == 0 {
+		return
+	}
+	s := &e.scopes[len(e.scopes)-1]
+	if !s.obj {
+		return
+	}
+	if !s.field {
+		e.checkFail("value", "missing field name")
+		return
+	}
+	s.field = false
+}
+
+// checkStart checks value and begins object or array.
+func (e *Encoder) checkStart(obj bool) {
+	e.checkValue()
+	e.scopes = append(e.scopes, encScope{obj: obj})
+}
+
+// checkField checks that field name can be written.
+func (e *Encoder) checkField() {
+	if len(e.scopes) == 0 || !e.scopes[len(e.scopes)-1].obj {
+		e.checkFail("FieldStart", "field outside of object")
+		return
+	}
+	s := &e.scopes[len(e.scopes)-1]
+	if s.field {
+		e.checkFail("FieldStart", "missing value of previous field")
+	}
+	s.field = true
+}
+
+// checkEnd checks and ends object or array.
+func (e *Encoder) checkEnd(obj bool) {
+	op := "ArrEnd"
+	if obj {
+		op = "ObjEnd"
+	}
+	if len(e.scopes) == 0 {
+		if obj {
+			e.checkFail(op, "no object to end")
+		} else {
+			e.checkFail(op, "no array to end")
+		}
+		return
+	}
+	s := e.scopes[len(e.scopes)-1]
+	switch {
+	case s.obj != obj && obj:
+		e.checkFail(op, "array is not ended")
+	case s.obj != obj:
+		e.checkFail(op, "object is not ended")
+	case s.field:
+		e.checkFail(op, "missing value of last field")
+	}
+	e.scopes = e.scopes[:len(e.scopes)-1]
+}
@@ -0,0 +1,203 @@
+package jx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_SetChecked(t *testing.T) {
+	for i, tt := range []struct {
+		Write  func(e *Encoder)
+		Op     string
+		Reason string
+	}{
+		{
+			Write: func(e *Encoder) {
+				e.ObjStart()
+				e.FieldStart("a")
+				e.Arr(func(e *Encoder) {
+					e.Int(1)
+					e.Obj(nil)
+					e.Obj(func(e *Encoder) {
+						e.Field("b", func(e *Encoder) { e.Null() })
+					})
+				})
+				e.FieldStart("c")
+				e.ArrEmpty()
+				e.ObjEnd()
+				e.Str("next")
+			},
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ObjEnd()
+			},
+			Op:     "ObjEnd",
+			Reason: "no object to end",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ArrStart()
+				e.ArrEnd()
+				e.ArrEnd()
+			},
+			Op:     "ArrEnd",
+			Reason: "no array to end",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ArrStart()
+				e.ObjEnd()
+			},
+			Op:     "ObjEnd",
+			Reason: "array is not ended",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ObjStart()
+				e.ArrEnd()
+			},
+			Op:     "ArrEnd",
+			Reason: "object is not ended",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ArrStart()
+				e.FieldStart("a")
+			},
+			Op:     "FieldStart",
+			Reason: "field outside of object",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.FieldStart("a")
+			},
+			Op:     "FieldStart",
+			Reason: "field outside of object",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ObjStart()
+				e.FieldStart("a")
+				e.FieldStart("b")
+			},
+			Op:     "FieldStart",
+			Reason: "missing value of previous field",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.Obj(func(e *Encoder) {
+					e.Field("a", func(e *Encoder) {})
+				})
+			},
+			Op:     "ObjEnd",
+			Reason: "missing value of last field",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ObjStart()
+				e.Int(1)
+			},
+			Op:     "value",
+			Reason: "missing field name",
+		},
+		{
+			Write: func(e *Encoder) {
+				e.ObjStart()
+				e.ArrStart()
+			},
+			Op:     "value",
+			Reason: "missing field name",
+		},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), func(t *testing.T) {
+			var unchecked, checked Encoder
+			checked.SetChecked(true)
+			tt.Write(&unchecked)
+			tt.Write(&checked)
+
+			// Output is not changed.
+			require.Equal(t, unchecked.String(), checked.String())
+			require.NoError(t, unchecked.Err())
+
+			err := checked.Err()
+			if tt.Op == "" {
+				require.NoError(t, err)
+				require.True(t, checked.Complete())
+				return
+			}
+			var encErr *EncoderError
+			require.ErrorAs(t, err, &encErr)
+			require.Equal(t, tt.Op, encErr.Op)
+			require.Equal(t, tt.Reason, encErr.Reason)
+			require.False(t, checked.Complete())
+
+			checked.Reset()
+			require.NoError(t, checked.Err())
+			require.True(t, checked.Complete())
+		})
+	}
+	t.Run("First", func(t *testing.T) {
+		var e Encoder
+		e.SetChecked(true)
+		e.ArrStart()
+		e.FieldStart("a")
+		e.Int(1)
+		e.ObjEnd()
+
+		var encErr *EncoderError
+		require.ErrorAs(t, e.Err(), &encErr)
+		require.Equal(t, "FieldStart", encErr.Op)
+		require.Equal(t, 1, encErr.Depth)
+		require.EqualError(t, encErr, "jx: FieldStart at depth 1: field outside of object")
+	})
+	t.Run("Incomplete", func(t *testing.T) {
+		var e Encoder
+		e.SetChecked(true)
+		e.ObjStart()
+		e.FieldStart("a")
+		require.NoError(t, e.Err())
+		require.False(t, e.Complete())
+		e.Int(1)
+		e.ObjEnd()
+		require.True(t, e.Complete())
+	})
+	t.Run("Stream", func(t *testing.T) {
+		writeErr := errors.New("write")
+		e := NewStreamingEncoder(&errWriter{err: writeErr}, minEncoderBufSize)
+		e.SetChecked(true)
+		e.Arr(func(e *Encoder) {
+			for i := 0; i < 100; i++ {
+				e.Int(i)
+			}
+		})
+		require.ErrorIs(t, e.Err(), writeErr)
+		require.False(t, e.Complete())
+	})
+}
+
+func BenchmarkEncoder_SetChecked(b *testing.B) {
+	for _, checked := range []bool{false, true} {
+		b.Run(fmt.Sprintf("%t", checked), func(b *testing.B) {
+			var e Encoder
+			e.SetChecked(checked)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				e.Reset()
+				e.Obj(func(e *Encoder) {
+					e.Field("a", func(e *Encoder) {
+						e.Arr(func(e *Encoder) {
+							e.Int(1)
+							e.Int(2)
+						})
+					})
+					e.Field("b", func(e *Encoder) { e.Str("c") })
+				})
+			}
+		})
+	}
+}
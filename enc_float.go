@@ -1,17 +1,41 @@
 package jx
 
+import "math"
+
 // Float32 encodes float32.
 //
-// NB: Infinities and NaN are represented as null.
+// NB: Infinities and NaN are represented as null by default, see
+// SetNonFiniteMode.
 func (e *Encoder) Float32(v float32) bool {
-	return e.comma() ||
-		e.w.Float32(v)
+	return e.float(float64(v), 32)
 }
 
 // Float64 encodes float64.
 //
-// NB: Infinities and NaN are represented as null.
+// NB: Infinities and NaN are represented as null by default, see
+// SetNonFiniteMode.
 func (e *Encoder) Float64(v float64) bool {
+	return e.float(v, 64)
+}
+
+func (e *Encoder) float(v float64, bits int) bool {
+	if e.w.opts.nonFinite == NonFiniteError && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		// Fail before writing separator.
+		return e.w.nonFinite(v)
+	}
 	return e.comma() ||
-		e.w.Float64(v)
+		e.w.Float(v, bits)
+}
+
+// SetFloatFormat sets formatting mode of Float32 and Float64.
+func (e *Encoder) SetFloatFormat(f FloatFormat) {
+	e.w.SetFloatFormat(f)
+}
+
+// SetNonFiniteMode sets policy for NaN and infinities of Float32 and
+// Float64.
+//
+// Error of NonFiniteError mode is returned by Err.
+func (e *Encoder) SetNonFiniteMode(mode NonFiniteMode) {
+	e.w.SetNonFiniteMode(mode)
 }
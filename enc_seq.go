@@ -0,0 +1,11 @@
+package jx
+
+// SeqRecord writes RFC 7464 json text sequence record: record separator,
+// value written by callback and line feed.
+//
+// Callback should write single top-level value.
+func (e *Encoder) SeqRecord(f func(e *Encoder)) (fail bool) {
+	fail = e.w.SeqStart()
+	f(e)
+	return fail || e.w.SeqEnd()
+}
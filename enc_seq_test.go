@@ -0,0 +1,61 @@
+package jx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_SeqRecord(t *testing.T) {
+	const expected = "\x1e{\"a\":1}\n\x1e[]\n\x1enull\n"
+	write := func(e *Encoder) {
+		e.SeqRecord(func(e *Encoder) {
+			e.Obj(func(e *Encoder) {
+				e.Field("a", func(e *Encoder) {
+					e.Int(1)
+				})
+			})
+		})
+		e.SeqRecord(func(e *Encoder) {
+			e.ArrEmpty()
+		})
+		e.SeqRecord(func(e *Encoder) {
+			e.Null()
+		})
+	}
+	t.Run("Buffer", func(t *testing.T) {
+		var e Encoder
+		write(&e)
+		require.Equal(t, expected, e.String())
+	})
+	t.Run("Streaming", func(t *testing.T) {
+		var buf bytes.Buffer
+		e := NewStreamingEncoder(&buf, minEncoderBufSize)
+		for i := 0; i < 10; i++ {
+			write(e)
+		}
+		require.NoError(t, e.Close())
+		require.Equal(t, bytes.Repeat([]byte(expected), 10), buf.Bytes())
+	})
+	t.Run("Writer", func(t *testing.T) {
+		var w Writer
+		w.SeqStart()
+		w.True()
+		w.SeqEnd()
+		require.Equal(t, "\x1etrue\n", w.String())
+	})
+	t.Run("Decode", func(t *testing.T) {
+		var e Encoder
+		write(&e)
+		iter := DecodeBytes(e.Bytes()).SeqIter()
+		n := 0
+		for iter.Next() {
+			_, err := iter.Raw()
+			require.NoError(t, err)
+			n++
+		}
+		require.NoError(t, iter.Err())
+		require.Equal(t, 3, n)
+	})
+}
@@ -132,3 +132,36 @@ func TestEncoder_StrEscape(t *testing.T) {
 		}, v)
 	})
 }
+
+func BenchmarkWriter_Str(b *testing.B) {
+	msg := strings.Repeat("request handled successfully, ", 8)
+	for _, bb := range []struct {
+		Name  string
+		Input string
+	}{
+		{"Short", "hello"},
+		{"Long", msg},
+		{"Escapes", msg + "\n\t" + msg + `"quoted"` + msg},
+		{"Unicode", strings.Repeat("запрос обработан успешно, ", 8)},
+	} {
+		bb := bb
+		for _, enc := range []struct {
+			Name  string
+			Write func(w *Writer, v string) bool
+		}{
+			{"Str", (*Writer).Str},
+			{"StrEscape", (*Writer).StrEscape},
+		} {
+			enc := enc
+			b.Run(bb.Name+"/"+enc.Name, func(b *testing.B) {
+				var w Writer
+				b.ReportAllocs()
+				b.SetBytes(int64(len(bb.Input)))
+				for i := 0; i < b.N; i++ {
+					w.Reset()
+					enc.Write(&w, bb.Input)
+				}
+			})
+		}
+	}
+}
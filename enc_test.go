@@ -2,6 +2,7 @@ package jx
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -140,6 +141,119 @@ func TestEncoder_Arr(t *testing.T) {
 	})
 }
 
+func TestEncoder_ObjErr(t *testing.T) {
+	errStop := errors.New("stop")
+	// rows writes fields until n-th, failing on it.
+	rows := func(n int) func(e *Encoder) error {
+		return func(e *Encoder) error {
+			for i := 0; ; i++ {
+				if err := e.FieldErr(fmt.Sprintf("r%d", i), func(e *Encoder) error {
+					if i == n {
+						return errStop
+					}
+					return e.ArrErr(func(e *Encoder) error {
+						e.Int(i)
+						return nil
+					})
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for i, tt := range []struct {
+		Write    func(e *Encoder) error
+		Expected string
+		Err      error
+	}{
+		{
+			Write: func(e *Encoder) error {
+				return e.ObjErr(func(e *Encoder) error {
+					return e.FieldErr("a", func(e *Encoder) error {
+						return e.ArrErr(func(e *Encoder) error {
+							e.Str("b")
+							return e.ObjErr(nil)
+						})
+					})
+				})
+			},
+			Expected: `{"a":["b",{}]}`,
+		},
+		{
+			Write: func(e *Encoder) error {
+				return e.ArrErr(nil)
+			},
+			Expected: `[]`,
+		},
+		{
+			Write: func(e *Encoder) error {
+				return e.ObjErr(rows(2))
+			},
+			Expected: `{"r0":[0],"r1":[1],"r2":null}`,
+			Err:      errStop,
+		},
+		{
+			Write: func(e *Encoder) error {
+				return e.ArrErr(func(e *Encoder) error {
+					e.Int(1)
+					return e.ObjErr(rows(0))
+				})
+			},
+			Expected: `[1,{"r0":null}]`,
+			Err:      errStop,
+		},
+		{
+			Write: func(e *Encoder) error {
+				return e.ObjErr(func(e *Encoder) error {
+					return e.FieldErr("a", func(e *Encoder) error {
+						e.Int(1)
+						return errStop
+					})
+				})
+			},
+			Expected: `{"a":1}`,
+			Err:      errStop,
+		},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), func(t *testing.T) {
+			testEncoderModes(t, func(e *Encoder) {
+				e.SetChecked(true)
+				err := tt.Write(e)
+				if tt.Err != nil {
+					require.ErrorIs(t, err, tt.Err)
+				} else {
+					require.NoError(t, err)
+				}
+				// Containers are closed.
+				require.True(t, e.Complete())
+			}, tt.Expected)
+		})
+	}
+	t.Run("Stream", func(t *testing.T) {
+		writeErr := errors.New("write")
+		e := NewStreamingEncoder(&errWriter{err: writeErr}, minEncoderBufSize)
+		calls := 0
+		err := e.ArrErr(func(e *Encoder) error {
+			for i := 0; i < 1000; i++ {
+				if err := e.ObjErr(func(e *Encoder) error {
+					calls++
+					return e.FieldErr("value", func(e *Encoder) error {
+						e.Int(i)
+						return nil
+					})
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, writeErr)
+		require.Less(t, calls, 1000)
+		require.ErrorIs(t, e.Close(), writeErr)
+	})
+}
+
 func BenchmarkEncoder_Arr(b *testing.B) {
 	b.Run("Manual", func(b *testing.B) {
 		b.ReportAllocs()
@@ -7,10 +7,13 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-faster/errors"
+
+	"github.com/go-faster/jx/internal/byteseq"
 )
 
 func addCorpus(f *testing.F) {
@@ -189,3 +192,206 @@ func FuzzValues(f *testing.F) {
 		}
 	})
 }
+
+// Escaping of Writer.Str and Writer.StrEscape before vectorized scanning,
+// copied verbatim, is the reference for FuzzWriter_Str.
+
+func baselineStr[S byteseq.Byteseq](w *Writer, v S) (fail bool) {
+	fail = w.byte('"')
+
+	// Fast path, without utf8 and escape support.
+	var (
+		i      = 0
+		length = len(v)
+	)
+	for ; i < length && !fail; i++ {
+		c := v[i]
+		if safeSet[c] != 0 {
+			break
+		}
+	}
+	fail = fail || writeStreamByteseq(w, v[:i])
+	if i == length {
+		return fail || w.byte('"')
+	}
+	return fail || baselineStrSlow[S](w, v[i:])
+}
+
+func baselineStrSlow[S byteseq.Byteseq](w *Writer, v S) (fail bool) {
+	var i, start int
+	// for the remaining parts, we process them char by char
+	for i < len(v) && !fail {
+		b := v[i]
+		if safeSet[b] == 0 {
+			i++
+			continue
+		}
+		if start < i {
+			fail = fail || writeStreamByteseq(w, v[start:i])
+		}
+
+		switch b {
+		case '\\', '"':
+			fail = fail || w.twoBytes('\\', b)
+		case '\n':
+			fail = fail || w.twoBytes('\\', 'n')
+		case '\r':
+			fail = fail || w.twoBytes('\\', 'r')
+		case '\t':
+			fail = fail || w.twoBytes('\\', 't')
+		default:
+			// This encodes bytes < 0x20 except for \t, \n and \r.
+			// If escapeHTML is set, it also escapes <, >, and &
+			// because they can lead to security holes when
+			// user-controlled strings are rendered into JSON
+			// and served to some browsers.
+			fail = fail || w.rawStr(`\u00`) || w.twoBytes(hexChars[b>>4], hexChars[b&0xF])
+		}
+		i++
+		start = i
+		continue
+	}
+	if start < len(v) {
+		fail = fail || writeStreamByteseq(w, v[start:])
+	}
+	return fail || w.byte('"')
+}
+
+func baselineStrEscape[S byteseq.Byteseq](w *Writer, v S) (fail bool) {
+	fail = w.byte('"')
+
+	// Fast path, probably does not require escaping.
+	var (
+		i      = 0
+		length = len(v)
+	)
+	for ; i < length && !fail; i++ {
+		c := v[i]
+		if c >= utf8.RuneSelf || !(htmlSafeSet[c]) {
+			break
+		}
+	}
+	fail = fail || writeStreamByteseq(w, v[:i])
+	if i == length {
+		return fail || w.byte('"')
+	}
+	return fail || baselineStrEscapeSlow[S](w, i, v, length)
+}
+
+func baselineStrEscapeSlow[S byteseq.Byteseq](w *Writer, i int, v S, valLen int) (fail bool) {
+	start := i
+	// for the remaining parts, we process them char by char
+	for i < valLen && !fail {
+		if b := v[i]; b < utf8.RuneSelf {
+			if htmlSafeSet[b] {
+				i++
+				continue
+			}
+			if start < i {
+				fail = fail || writeStreamByteseq(w, v[start:i])
+			}
+
+			switch b {
+			case '\\', '"':
+				fail = fail || w.twoBytes('\\', b)
+			case '\n':
+				fail = fail || w.twoBytes('\\', 'n')
+			case '\r':
+				fail = fail || w.twoBytes('\\', 'r')
+			case '\t':
+				fail = fail || w.twoBytes('\\', 't')
+			default:
+				// This encodes bytes < 0x20 except for \t, \n and \r.
+				// If escapeHTML is set, it also escapes <, >, and &
+				// because they can lead to security holes when
+				// user-controlled strings are rendered into JSON
+				// and served to some browsers.
+				fail = fail || w.rawStr(`\u00`) || w.twoBytes(hexChars[b>>4], hexChars[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := byteseq.DecodeRuneInByteseq(v[i:])
+		if c == utf8.RuneError && size == 1 {
+			if start < i {
+				fail = fail || writeStreamByteseq(w, v[start:i])
+			}
+			fail = fail || w.rawStr(`\ufffd`)
+			i++
+			start = i
+			continue
+		}
+		// U+2028 is LINE SEPARATOR.
+		// U+2029 is PARAGRAPH SEPARATOR.
+		// They are both technically valid characters in JSON strings,
+		// but don't work in JSONP, which has to be evaluated as JavaScript,
+		// and can lead to security holes there. It is valid JSON to
+		// escape them, so we do so unconditionally.
+		// See http://timelessrepo.com/json-isnt-a-javascript-subset for discussion.
+		if c == '\u2028' || c == '\u2029' {
+			if start < i {
+				fail = fail || writeStreamByteseq(w, v[start:i])
+			}
+			fail = fail || w.rawStr(`\u202`) || w.byte(hexChars[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	if start < len(v) {
+		fail = fail || writeStreamByteseq(w, v[start:])
+	}
+	return fail || w.byte('"')
+}
+
+func FuzzWriter_Str(f *testing.F) {
+	for _, s := range []string{
+		"",
+		"hello",
+		strings.Repeat("a", 100) + "\n" + strings.Repeat("b", 100),
+		strings.Repeat("<привет>", 10) + "\xff\u2028 &",
+		strings.Repeat("\x00\"\\", 20),
+	} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, escape := range []bool{false, true} {
+			var ref Writer
+			if escape {
+				baselineStrEscape(&ref, data)
+			} else {
+				baselineStr(&ref, data)
+			}
+			expected := ref.Buf
+			for _, write := range []func(w *Writer){
+				func(w *Writer) {
+					if escape {
+						w.StrEscape(string(data))
+					} else {
+						w.Str(string(data))
+					}
+				},
+				func(w *Writer) {
+					if escape {
+						w.ByteStrEscape(data)
+					} else {
+						w.ByteStr(data)
+					}
+				},
+			} {
+				var w Writer
+				write(&w)
+				require.Equal(t, string(expected), w.String())
+
+				// Small buffer to flush string by parts.
+				var buf bytes.Buffer
+				e := NewStreamingEncoder(&buf, 16)
+				write(&e.w)
+				require.NoError(t, e.Close())
+				require.Equal(t, string(expected), buf.String())
+			}
+		}
+	})
+}
@@ -37,8 +37,11 @@ func GetDecoder() *Decoder {
 }
 
 // PutDecoder puts *Decoder into pool.
+//
+// Decoder options are reset to defaults.
 func PutDecoder(d *Decoder) {
 	d.Reset(nil)
+	d.opts = decoderOptions{}
 	decPool.Put(d)
 }
 
@@ -48,9 +51,13 @@ func GetEncoder() *Encoder {
 }
 
 // PutEncoder puts *Encoder to pool
+//
+// Encoder options are reset to defaults.
 func PutEncoder(e *Encoder) {
 	e.Reset()
 	e.SetIdent(0)
+	e.SetChecked(false)
+	e.w.opts = writerOptions{}
 	encPool.Put(e)
 }
 
@@ -60,7 +67,10 @@ func GetWriter() *Writer {
 }
 
 // PutWriter puts *Writer to pool
+//
+// Writer options are reset to defaults.
 func PutWriter(e *Writer) {
 	e.Reset()
+	e.opts = writerOptions{}
 	writerPool.Put(e)
 }
@@ -53,7 +53,8 @@ func (n Num) floatAsInt() (dotIdx int, _ error) {
 		switch c {
 		case '0', '"': // ok
 		default:
-			return dotIdx, errors.Errorf("non-zero fractional part %q at %d", c, i)
+			err := errors.Errorf("non-zero fractional part %q at %d", c, i)
+			return dotIdx, classify(ErrInvalidNumber, err)
 		}
 	}
 	return dotIdx, nil
@@ -0,0 +1,125 @@
+package jx
+
+import (
+	"math/bits"
+	"unicode/utf8"
+
+	"github.com/go-faster/jx/internal/byteseq"
+)
+
+// Portable scanning of 8 bytes at once (SWAR), used for short inputs and on
+// platforms without vectorized implementation.
+//
+// Scanning covers string bodies and whitespace only. Structural characters
+// of objects and arrays are detected byte by byte by Skip and Validate.
+
+const (
+	swarLSB uint64 = 0x0101010101010101
+	swarMSB uint64 = 0x8080808080808080
+)
+
+// swarLoad loads 8 bytes of b starting from i as little-endian word.
+func swarLoad[S byteseq.Byteseq](b S, i int) uint64 {
+	b = b[i : i+8]
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+}
+
+// swarNonZero returns word with high bit set for every nonzero byte of v.
+func swarNonZero(v uint64) uint64 {
+	return ((v &^ swarMSB) + ^swarMSB | v) & swarMSB
+}
+
+// swarEqual returns word with high bit set for every byte of v equal to c.
+func swarEqual(v uint64, c byte) uint64 {
+	return ^swarNonZero(v^uint64(c)*swarLSB) & swarMSB
+}
+
+// swarStr returns word with high bit set for every byte of v that can't be
+// part of json string as is.
+func swarStr(v uint64) uint64 {
+	ctl := ^((v &^ swarMSB) + 0x60*swarLSB | v) & swarMSB
+	return ctl | swarEqual(v, '"') | swarEqual(v, '\\')
+}
+
+// swarIndex returns index of first byte with high bit set in m.
+func swarIndex(m uint64) int {
+	return bits.TrailingZeros64(m) / 8
+}
+
+// scanStrGeneric returns index of first byte of b that can't be part of
+// json string as is, i.e. quote, backslash or control character, or len(b)
+// if there is no such byte.
+func scanStrGeneric[S byteseq.Byteseq](b S) int {
+	i := 0
+	for ; len(b)-i >= 8; i += 8 {
+		if m := swarStr(swarLoad(b, i)); m != 0 {
+			return i + swarIndex(m)
+		}
+	}
+	for ; i < len(b); i++ {
+		if safeSet[b[i]] != 0 {
+			return i
+		}
+	}
+	return len(b)
+}
+
+// scanStrASCIIGeneric is scanStrGeneric that also stops at non-ASCII bytes.
+func scanStrASCIIGeneric(b []byte) int {
+	i := 0
+	for ; len(b)-i >= 8; i += 8 {
+		v := swarLoad(b, i)
+		if m := swarStr(v) | v&swarMSB; m != 0 {
+			return i + swarIndex(m)
+		}
+	}
+	for ; i < len(b); i++ {
+		if c := b[i]; c >= utf8.RuneSelf || safeSet[c] != 0 {
+			return i
+		}
+	}
+	return len(b)
+}
+
+// scanEscapeGeneric is scanStrGeneric that also stops at html special
+// characters and non-ASCII bytes.
+func scanEscapeGeneric[S byteseq.Byteseq](b S) int {
+	i := 0
+	for ; len(b)-i >= 8; i += 8 {
+		v := swarLoad(b, i)
+		m := swarStr(v) | v&swarMSB |
+			swarEqual(v, '<') | swarEqual(v, '>') | swarEqual(v, '&')
+		if m != 0 {
+			return i + swarIndex(m)
+		}
+	}
+	for ; i < len(b); i++ {
+		if c := b[i]; c >= utf8.RuneSelf || !htmlSafeSet[c] {
+			return i
+		}
+	}
+	return len(b)
+}
+
+// scanSpaceGeneric returns index of first non-whitespace byte of b, or len(b)
+// if there is no such byte.
+func scanSpaceGeneric(b []byte) int {
+	i := 0
+	for ; len(b)-i >= 8; i += 8 {
+		v := swarLoad(b, i)
+		m := swarNonZero(v^' '*swarLSB) &
+			swarNonZero(v^'\t'*swarLSB) &
+			swarNonZero(v^'\n'*swarLSB) &
+			swarNonZero(v^'\r'*swarLSB)
+		if m != 0 {
+			return i + swarIndex(m)
+		}
+	}
+	for ; i < len(b); i++ {
+		if spaceSet[b[i]] == 0 {
+			return i
+		}
+	}
+	return len(b)
+}
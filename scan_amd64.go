@@ -0,0 +1,91 @@
+//go:build amd64 && !purego
+
+package jx
+
+import (
+	"github.com/segmentio/asm/cpu"
+	"github.com/segmentio/asm/cpu/x86"
+
+	"github.com/go-faster/jx/internal/byteseq"
+)
+
+// scanAVX2 selects AVX2 loops of vectorized functions, SSE2 loops are used
+// otherwise.
+var scanAVX2 = cpu.X86.Has(x86.AVX2)
+
+// Vectorized functions process 16-byte blocks of input and return its
+// length rounded down to 16 if there is no matching byte in blocks.
+
+//go:noescape
+func scanStrVec(b []byte) int
+
+//go:noescape
+func scanStrVecString(s string) int
+
+//go:noescape
+func scanStrASCIIVec(b []byte) int
+
+//go:noescape
+func scanEscapeVec(b []byte) int
+
+//go:noescape
+func scanEscapeVecString(s string) int
+
+//go:noescape
+func scanSpaceVec(b []byte) int
+
+func scanStr[S byteseq.Byteseq](b S) int {
+	if len(b) < 16 {
+		return scanStrGeneric(b)
+	}
+	var i int
+	switch b := any(b).(type) {
+	case string:
+		i = scanStrVecString(b)
+	case []byte:
+		i = scanStrVec(b)
+	}
+	if i == len(b)&^15 {
+		i += scanStrGeneric(b[i:])
+	}
+	return i
+}
+
+func scanStrASCII(b []byte) int {
+	if len(b) < 16 {
+		return scanStrASCIIGeneric(b)
+	}
+	i := scanStrASCIIVec(b)
+	if i == len(b)&^15 {
+		i += scanStrASCIIGeneric(b[i:])
+	}
+	return i
+}
+
+func scanEscape[S byteseq.Byteseq](b S) int {
+	if len(b) < 16 {
+		return scanEscapeGeneric(b)
+	}
+	var i int
+	switch b := any(b).(type) {
+	case string:
+		i = scanEscapeVecString(b)
+	case []byte:
+		i = scanEscapeVec(b)
+	}
+	if i == len(b)&^15 {
+		i += scanEscapeGeneric(b[i:])
+	}
+	return i
+}
+
+func scanSpace(b []byte) int {
+	if len(b) < 16 {
+		return scanSpaceGeneric(b)
+	}
+	i := scanSpaceVec(b)
+	if i == len(b)&^15 {
+		i += scanSpaceGeneric(b[i:])
+	}
+	return i
+}
@@ -0,0 +1,368 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// Scanning functions process blocks of SI with length CX, returning in AX
+// index of first matching byte, or CX rounded down to 16 if there is no such
+// byte in blocks.
+//
+// With AVX2, 32-byte blocks are processed and the last 16-byte block, if
+// any, is processed by SSE2 loop, which is used for whole input otherwise.
+// SSE2 is part of amd64 baseline, so it needs no CPU feature check.
+
+// BROADCAST sets every byte of Y register to constant.
+#define BROADCAST(c, X, Y) \
+	MOVQ $c, DX; VMOVQ DX, X; VPBROADCASTB X, Y
+
+// SPLAT sets every byte of X register to constant, using only SSE2.
+#define SPLAT(c, X) \
+	MOVQ $c, DX; MOVQ DX, X; PUNPCKLBW X, X; PSHUFLW $0, X, X; PUNPCKLQDQ X, X
+
+// scanStrBody finds quote, backslash or control character.
+TEXT scanStrBody<>(SB), NOSPLIT, $0-0
+	ANDQ $~15, CX
+	XORQ AX, AX
+	CMPB ·scanAVX2(SB), $0
+	JEQ  strSSE
+	BROADCAST(0x22, X1, Y1) // '"'
+	BROADCAST(0x5c, X2, Y2) // '\\'
+	BROADCAST(0x1f, X3, Y3) // last control character
+
+strLoop:
+	LEAQ      32(AX), DX
+	CMPQ      DX, CX
+	JA        strTail
+	VMOVDQU   (SI)(AX*1), Y0
+	VPCMPEQB  Y0, Y1, Y4
+	VPCMPEQB  Y0, Y2, Y5
+	VPOR      Y4, Y5, Y4
+	VPMINUB   Y0, Y3, Y5
+	VPCMPEQB  Y0, Y5, Y5 // c <= 0x1f
+	VPOR      Y4, Y5, Y4
+	VPMOVMSKB Y4, DX
+	TESTL     DX, DX
+	JNZ       strFound
+	ADDQ      $32, AX
+	JMP       strLoop
+
+strFound:
+	VZEROUPPER
+	BSFL DX, DX
+	ADDQ DX, AX
+	RET
+
+strTail:
+	VZEROUPPER
+
+strSSE:
+	SPLAT(0x22, X1) // '"'
+	SPLAT(0x5c, X2) // '\\'
+	SPLAT(0x1f, X3) // last control character
+
+strSSELoop:
+	CMPQ     AX, CX
+	JAE      strDone
+	MOVOU    (SI)(AX*1), X0
+	MOVO     X1, X4
+	PCMPEQB  X0, X4
+	MOVO     X2, X5
+	PCMPEQB  X0, X5
+	POR      X5, X4
+	MOVO     X3, X5
+	PMINUB   X0, X5
+	PCMPEQB  X0, X5 // c <= 0x1f
+	POR      X5, X4
+	PMOVMSKB X4, DX
+	TESTL    DX, DX
+	JNZ      strSSEFound
+	ADDQ     $16, AX
+	JMP      strSSELoop
+
+strSSEFound:
+	BSFL DX, DX
+	ADDQ DX, AX
+
+strDone:
+	RET
+
+// scanStrASCIIBody is scanStrBody that also finds non-ASCII bytes.
+TEXT scanStrASCIIBody<>(SB), NOSPLIT, $0-0
+	ANDQ $~15, CX
+	XORQ AX, AX
+	CMPB ·scanAVX2(SB), $0
+	JEQ  asciiSSE
+	BROADCAST(0x22, X1, Y1) // '"'
+	BROADCAST(0x5c, X2, Y2) // '\\'
+	BROADCAST(0x1f, X3, Y3) // last control character
+
+asciiLoop:
+	LEAQ      32(AX), DX
+	CMPQ      DX, CX
+	JA        asciiTail
+	VMOVDQU   (SI)(AX*1), Y0
+	VPCMPEQB  Y0, Y1, Y4
+	VPCMPEQB  Y0, Y2, Y5
+	VPOR      Y4, Y5, Y4
+	VPMINUB   Y0, Y3, Y5
+	VPCMPEQB  Y0, Y5, Y5 // c <= 0x1f
+	VPOR      Y4, Y5, Y4
+	VPOR      Y4, Y0, Y4 // c >= 0x80
+	VPMOVMSKB Y4, DX
+	TESTL     DX, DX
+	JNZ       asciiFound
+	ADDQ      $32, AX
+	JMP       asciiLoop
+
+asciiFound:
+	VZEROUPPER
+	BSFL DX, DX
+	ADDQ DX, AX
+	RET
+
+asciiTail:
+	VZEROUPPER
+
+asciiSSE:
+	SPLAT(0x22, X1) // '"'
+	SPLAT(0x5c, X2) // '\\'
+	SPLAT(0x1f, X3) // last control character
+
+asciiSSELoop:
+	CMPQ     AX, CX
+	JAE      asciiDone
+	MOVOU    (SI)(AX*1), X0
+	MOVO     X1, X4
+	PCMPEQB  X0, X4
+	MOVO     X2, X5
+	PCMPEQB  X0, X5
+	POR      X5, X4
+	MOVO     X3, X5
+	PMINUB   X0, X5
+	PCMPEQB  X0, X5 // c <= 0x1f
+	POR      X5, X4
+	POR      X0, X4 // c >= 0x80
+	PMOVMSKB X4, DX
+	TESTL    DX, DX
+	JNZ      asciiSSEFound
+	ADDQ     $16, AX
+	JMP      asciiSSELoop
+
+asciiSSEFound:
+	BSFL DX, DX
+	ADDQ DX, AX
+
+asciiDone:
+	RET
+
+// scanEscapeBody is scanStrBody that also finds html special characters
+// and non-ASCII bytes.
+TEXT scanEscapeBody<>(SB), NOSPLIT, $0-0
+	ANDQ $~15, CX
+	XORQ AX, AX
+	CMPB ·scanAVX2(SB), $0
+	JEQ  escapeSSE
+	BROADCAST(0x22, X1, Y1) // '"'
+	BROADCAST(0x5c, X2, Y2) // '\\'
+	BROADCAST(0x1f, X3, Y3) // last control character
+	BROADCAST(0x3c, X4, Y4) // '<'
+	BROADCAST(0x3e, X5, Y5) // '>'
+	BROADCAST(0x26, X6, Y6) // '&'
+
+escapeLoop:
+	LEAQ      32(AX), DX
+	CMPQ      DX, CX
+	JA        escapeTail
+	VMOVDQU   (SI)(AX*1), Y0
+	VPCMPEQB  Y0, Y1, Y7
+	VPCMPEQB  Y0, Y2, Y8
+	VPOR      Y7, Y8, Y7
+	VPMINUB   Y0, Y3, Y8
+	VPCMPEQB  Y0, Y8, Y8 // c <= 0x1f
+	VPOR      Y7, Y8, Y7
+	VPCMPEQB  Y0, Y4, Y8
+	VPOR      Y7, Y8, Y7
+	VPCMPEQB  Y0, Y5, Y8
+	VPOR      Y7, Y8, Y7
+	VPCMPEQB  Y0, Y6, Y8
+	VPOR      Y7, Y8, Y7
+	VPOR      Y7, Y0, Y7 // c >= 0x80
+	VPMOVMSKB Y7, DX
+	TESTL     DX, DX
+	JNZ       escapeFound
+	ADDQ      $32, AX
+	JMP       escapeLoop
+
+escapeFound:
+	VZEROUPPER
+	BSFL DX, DX
+	ADDQ DX, AX
+	RET
+
+escapeTail:
+	VZEROUPPER
+
+escapeSSE:
+	SPLAT(0x22, X1) // '"'
+	SPLAT(0x5c, X2) // '\\'
+	SPLAT(0x1f, X3) // last control character
+	SPLAT(0x3c, X4) // '<'
+	SPLAT(0x3e, X5) // '>'
+	SPLAT(0x26, X6) // '&'
+
+escapeSSELoop:
+	CMPQ     AX, CX
+	JAE      escapeDone
+	MOVOU    (SI)(AX*1), X0
+	MOVO     X1, X7
+	PCMPEQB  X0, X7
+	MOVO     X2, X8
+	PCMPEQB  X0, X8
+	POR      X8, X7
+	MOVO     X3, X8
+	PMINUB   X0, X8
+	PCMPEQB  X0, X8 // c <= 0x1f
+	POR      X8, X7
+	MOVO     X4, X8
+	PCMPEQB  X0, X8
+	POR      X8, X7
+	MOVO     X5, X8
+	PCMPEQB  X0, X8
+	POR      X8, X7
+	MOVO     X6, X8
+	PCMPEQB  X0, X8
+	POR      X8, X7
+	POR      X0, X7 // c >= 0x80
+	PMOVMSKB X7, DX
+	TESTL    DX, DX
+	JNZ      escapeSSEFound
+	ADDQ     $16, AX
+	JMP      escapeSSELoop
+
+escapeSSEFound:
+	BSFL DX, DX
+	ADDQ DX, AX
+
+escapeDone:
+	RET
+
+// scanSpaceBody finds non-whitespace character.
+TEXT scanSpaceBody<>(SB), NOSPLIT, $0-0
+	ANDQ $~15, CX
+	XORQ AX, AX
+	CMPB ·scanAVX2(SB), $0
+	JEQ  spaceSSE
+	BROADCAST(0x20, X1, Y1) // ' '
+	BROADCAST(0x09, X2, Y2) // '\t'
+	BROADCAST(0x0a, X3, Y3) // '\n'
+	BROADCAST(0x0d, X4, Y4) // '\r'
+
+spaceLoop:
+	LEAQ      32(AX), DX
+	CMPQ      DX, CX
+	JA        spaceTail
+	VMOVDQU   (SI)(AX*1), Y0
+	VPCMPEQB  Y0, Y1, Y5
+	VPCMPEQB  Y0, Y2, Y6
+	VPOR      Y5, Y6, Y5
+	VPCMPEQB  Y0, Y3, Y6
+	VPOR      Y5, Y6, Y5
+	VPCMPEQB  Y0, Y4, Y6
+	VPOR      Y5, Y6, Y5
+	VPMOVMSKB Y5, DX
+	NOTL      DX
+	TESTL     DX, DX
+	JNZ       spaceFound
+	ADDQ      $32, AX
+	JMP       spaceLoop
+
+spaceFound:
+	VZEROUPPER
+	BSFL DX, DX
+	ADDQ DX, AX
+	RET
+
+spaceTail:
+	VZEROUPPER
+
+spaceSSE:
+	SPLAT(0x20, X1) // ' '
+	SPLAT(0x09, X2) // '\t'
+	SPLAT(0x0a, X3) // '\n'
+	SPLAT(0x0d, X4) // '\r'
+
+spaceSSELoop:
+	CMPQ     AX, CX
+	JAE      spaceDone
+	MOVOU    (SI)(AX*1), X0
+	MOVO     X1, X5
+	PCMPEQB  X0, X5
+	MOVO     X2, X6
+	PCMPEQB  X0, X6
+	POR      X6, X5
+	MOVO     X3, X6
+	PCMPEQB  X0, X6
+	POR      X6, X5
+	MOVO     X4, X6
+	PCMPEQB  X0, X6
+	POR      X6, X5
+	PMOVMSKB X5, DX
+	XORL     $0xffff, DX
+	TESTL    DX, DX
+	JNZ      spaceSSEFound
+	ADDQ     $16, AX
+	JMP      spaceSSELoop
+
+spaceSSEFound:
+	BSFL DX, DX
+	ADDQ DX, AX
+
+spaceDone:
+	RET
+
+// func scanStrVec(b []byte) int
+TEXT ·scanStrVec(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	CALL scanStrBody<>(SB)
+	MOVQ AX, ret+24(FP)
+	RET
+
+// func scanStrVecString(s string) int
+TEXT ·scanStrVecString(SB), NOSPLIT, $0-24
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), CX
+	CALL scanStrBody<>(SB)
+	MOVQ AX, ret+16(FP)
+	RET
+
+// func scanStrASCIIVec(b []byte) int
+TEXT ·scanStrASCIIVec(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	CALL scanStrASCIIBody<>(SB)
+	MOVQ AX, ret+24(FP)
+	RET
+
+// func scanEscapeVec(b []byte) int
+TEXT ·scanEscapeVec(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	CALL scanEscapeBody<>(SB)
+	MOVQ AX, ret+24(FP)
+	RET
+
+// func scanEscapeVecString(s string) int
+TEXT ·scanEscapeVecString(SB), NOSPLIT, $0-24
+	MOVQ s_base+0(FP), SI
+	MOVQ s_len+8(FP), CX
+	CALL scanEscapeBody<>(SB)
+	MOVQ AX, ret+16(FP)
+	RET
+
+// func scanSpaceVec(b []byte) int
+TEXT ·scanSpaceVec(SB), NOSPLIT, $0-32
+	MOVQ b_base+0(FP), SI
+	MOVQ b_len+8(FP), CX
+	CALL scanSpaceBody<>(SB)
+	MOVQ AX, ret+24(FP)
+	RET
@@ -0,0 +1,23 @@
+//go:build amd64 && !purego
+
+package jx
+
+import "testing"
+
+func TestScan_SSE2(t *testing.T) {
+	if !scanAVX2 {
+		t.Skip("SSE2 is tested by TestScan")
+	}
+	scanAVX2 = false
+	defer func() { scanAVX2 = true }()
+	TestScan(t)
+}
+
+func BenchmarkScan_SSE2(b *testing.B) {
+	if !scanAVX2 {
+		b.Skip("SSE2 is benchmarked by BenchmarkScan")
+	}
+	scanAVX2 = false
+	defer func() { scanAVX2 = true }()
+	BenchmarkScan(b)
+}
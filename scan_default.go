@@ -0,0 +1,21 @@
+//go:build !amd64 || purego
+
+package jx
+
+import "github.com/go-faster/jx/internal/byteseq"
+
+func scanStr[S byteseq.Byteseq](b S) int {
+	return scanStrGeneric(b)
+}
+
+func scanStrASCII(b []byte) int {
+	return scanStrASCIIGeneric(b)
+}
+
+func scanEscape[S byteseq.Byteseq](b S) int {
+	return scanEscapeGeneric(b)
+}
+
+func scanSpace(b []byte) int {
+	return scanSpaceGeneric(b)
+}
@@ -0,0 +1,146 @@
+package jx
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestScan(t *testing.T) {
+	naive := func(set *[256]byte, stop byte) func(b []byte) int {
+		return func(b []byte) int {
+			for i, c := range b {
+				if set[c] == stop {
+					return i
+				}
+			}
+			return len(b)
+		}
+	}
+	htmlSet := [256]byte{}
+	asciiSet := safeSet
+	for c := range htmlSet {
+		if c >= utf8.RuneSelf || !htmlSafeSet[c] {
+			htmlSet[c] = 1
+		}
+		if c >= utf8.RuneSelf {
+			asciiSet[c] = 1
+		}
+	}
+	for _, tt := range []struct {
+		Name  string
+		Set   *[256]byte
+		Stop  byte
+		Scans []func(b []byte) int
+	}{
+		{"Str", &safeSet, 1, []func(b []byte) int{
+			scanStr[[]byte],
+			scanStrGeneric[[]byte],
+			func(b []byte) int { return scanStr(string(b)) },
+			func(b []byte) int { return scanStrGeneric(string(b)) },
+		}},
+		{"StrASCII", &asciiSet, 1, []func(b []byte) int{scanStrASCII, scanStrASCIIGeneric}},
+		{"Escape", &htmlSet, 1, []func(b []byte) int{
+			scanEscape[[]byte],
+			scanEscapeGeneric[[]byte],
+			func(b []byte) int { return scanEscape(string(b)) },
+			func(b []byte) int { return scanEscapeGeneric(string(b)) },
+		}},
+		{"Space", &spaceSet, 0, []func(b []byte) int{scanSpace, scanSpaceGeneric}},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			expected := naive(tt.Set, tt.Stop)
+			// Filler is byte that does not stop the scan.
+			filler := byte('a')
+			if tt.Stop == 0 {
+				filler = ' '
+			}
+			for n := 0; n < 100; n++ {
+				b := make([]byte, n)
+				for i := range b {
+					b[i] = filler
+				}
+				// Plain comparisons, the loop is too hot for require.
+				check := func(b []byte, want int, pos, c int) {
+					for i, scan := range tt.Scans {
+						if got := scan(b); got != want {
+							t.Fatalf("Test%d: len %d, pos %d, byte %q: got %d, want %d", i+1, len(b), pos, c, got, want)
+						}
+					}
+				}
+				check(b, n, -1, -1)
+				for pos := 0; pos < n; pos++ {
+					for c := 0; c < 256; c++ {
+						if tt.Set[c] != tt.Stop {
+							continue
+						}
+						b[pos] = byte(c)
+						check(b, expected(b), pos, c)
+					}
+					// Second stop byte after first one.
+					if pos > 0 {
+						check(append(b[:pos-1:pos-1], b[pos:]...), pos-1, pos, int(b[pos]))
+					}
+					b[pos] = filler
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkValid_Long(b *testing.B) {
+	long := `"` + strings.Repeat("a", 500) + `",`
+	indent := "\n" + strings.Repeat(" ", 40)
+	for _, bb := range []struct {
+		Name string
+		Data []byte
+	}{
+		{"Str", []byte("[" + strings.Repeat(long, 100) + "1]")},
+		{"Space", []byte("[" + strings.Repeat(indent+"1,", 100) + "1]")},
+	} {
+		bb := bb
+		b.Run(bb.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bb.Data)))
+			for i := 0; i < b.N; i++ {
+				if !Valid(bb.Data) {
+					b.Fatal("invalid")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	str := make([]byte, 1024)
+	space := make([]byte, 1024)
+	for i := range str {
+		str[i] = 'a'
+		space[i] = ' '
+	}
+	for _, bb := range []struct {
+		Name string
+		Data []byte
+		Scan func(b []byte) int
+	}{
+		{"Str", str, scanStr[[]byte]},
+		{"StrGeneric", str, scanStrGeneric[[]byte]},
+		{"StrASCII", str, scanStrASCII},
+		{"StrASCIIGeneric", str, scanStrASCIIGeneric},
+		{"Escape", str, scanEscape[[]byte]},
+		{"EscapeGeneric", str, scanEscapeGeneric[[]byte]},
+		{"Space", space, scanSpace},
+		{"SpaceGeneric", space, scanSpaceGeneric},
+	} {
+		bb := bb
+		b.Run(bb.Name, func(b *testing.B) {
+			b.SetBytes(int64(len(bb.Data)))
+			for i := 0; i < b.N; i++ {
+				if bb.Scan(bb.Data) != len(bb.Data) {
+					b.Fatal("unexpected stop")
+				}
+			}
+		})
+	}
+}
@@ -11,6 +11,15 @@ import (
 type Writer struct {
 	Buf    []byte // underlying buffer
 	stream *streamState
+
+	// opts are writing options, preserved across Reset and ResetWriter.
+	opts writerOptions
+	err  error // first error recorded in NonFiniteError mode
+}
+
+type writerOptions struct {
+	float     FloatFormat   // see SetFloatFormat
+	nonFinite NonFiniteMode // see SetNonFiniteMode
 }
 
 // Write implements io.Writer.
@@ -43,6 +52,7 @@ func (w Writer) String() string {
 func (w *Writer) Reset() {
 	w.Buf = w.Buf[:0]
 	w.stream = nil
+	w.err = nil
 }
 
 // ResetWriter resets underlying buffer and sets output writer.
@@ -52,6 +62,7 @@ func (w *Writer) ResetWriter(out io.Writer) {
 		w.stream = newStreamState(out)
 	}
 	w.stream.Reset(out)
+	w.err = nil
 }
 
 // Grow grows the underlying buffer.
@@ -1,11 +1,119 @@
 package jx
 
+import (
+	"math"
+
+	"github.com/go-faster/errors"
+)
+
+// FloatFormat is a formatting mode of floating-point numbers.
+type FloatFormat byte
+
+const (
+	// FloatDefault formats floats like encoding/json.
+	//
+	// This is default mode.
+	FloatDefault FloatFormat = iota
+	// FloatES formats floats like ECMAScript Number.prototype.toString and
+	// JSON.stringify: shortest representation that round-trips, with
+	// exponent for absolute values below 1e-6 or from 1e21.
+	//
+	// Unlike FloatDefault, negative zero is written as 0, and float32 values
+	// are written as float64 values, like JavaScript sees them.
+	FloatES
+)
+
+// SetFloatFormat sets formatting mode of Float, Float32 and Float64.
+func (w *Writer) SetFloatFormat(f FloatFormat) {
+	w.opts.float = f
+}
+
+// NonFiniteMode is a policy for NaN and infinities, which are not
+// representable in json.
+type NonFiniteMode byte
+
+const (
+	// NonFiniteNull writes null, like JSON.stringify.
+	//
+	// This is default mode.
+	NonFiniteNull NonFiniteMode = iota
+	// NonFiniteError writes nothing and fails, recording error matching
+	// ErrNonFinite.
+	NonFiniteError
+	// NonFiniteString writes strings "NaN", "Infinity" and "-Infinity".
+	//
+	// See Decoder.SetNonFiniteStrings to decode them.
+	NonFiniteString
+	// NonFiniteLiteral writes JSON5 literals NaN, Infinity and -Infinity.
+	//
+	// Output is not valid json.
+	NonFiniteLiteral
+)
+
+// ErrNonFinite means that NaN or infinity can not be written.
+//
+// Returned only in NonFiniteError mode.
+var ErrNonFinite = errors.New("non-finite float")
+
+// SetNonFiniteMode sets policy for NaN and infinities of Float, Float32
+// and Float64.
+func (w *Writer) SetNonFiniteMode(mode NonFiniteMode) {
+	w.opts.nonFinite = mode
+}
+
+// Err returns first error recorded in NonFiniteError mode, if any, or
+// write error in streaming mode.
+func (w *Writer) Err() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.stream != nil {
+		return w.stream.writeErr
+	}
+	return nil
+}
+
 // Float32 encodes float32.
 //
-// NB: Infinities and NaN are represented as null.
+// NB: Infinities and NaN are represented as null by default, see
+// SetNonFiniteMode.
 func (w *Writer) Float32(v float32) bool { return w.Float(float64(v), 32) }
 
 // Float64 encodes float64.
 //
-// NB: Infinities and NaN are represented as null.
+// NB: Infinities and NaN are represented as null by default, see
+// SetNonFiniteMode.
 func (w *Writer) Float64(v float64) bool { return w.Float(v, 64) }
+
+// nonFinite writes NaN or infinity according to policy.
+func (w *Writer) nonFinite(v float64) bool {
+	switch w.opts.nonFinite {
+	case NonFiniteError:
+		if w.err == nil {
+			w.err = errors.Wrap(ErrNonFinite, nonFiniteName(v))
+		}
+		return true
+	case NonFiniteString:
+		return w.byte('"') || w.rawStr(nonFiniteName(v)) || w.byte('"')
+	case NonFiniteLiteral:
+		return w.rawStr(nonFiniteName(v))
+	default:
+		// Like in ECMA:
+		// NaN and Infinity regardless of sign are represented
+		// as the String null.
+		//
+		// JSON.stringify({"foo":NaN}) -> {"foo":null}
+		return w.Null()
+	}
+}
+
+func nonFiniteName(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	default:
+		return "NaN"
+	}
+}
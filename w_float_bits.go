@@ -12,14 +12,12 @@ import (
 // Float writes float value to buffer.
 func (w *Writer) Float(v float64, bits int) bool {
 	if math.IsNaN(v) || math.IsInf(v, 0) {
-		// Like in ECMA:
-		// NaN and Infinity regardless of sign are represented
-		// as the String null.
-		//
-		// JSON.stringify({"foo":NaN}) -> {"foo":null}
-		return w.Null()
+		return w.nonFinite(v)
 	}
 
+	if w.opts.float == FloatES {
+		return w.floatES(v)
+	}
 	switch s := w.stream; {
 	case s == nil:
 		w.Buf = floatAppend(w.Buf, v, bits)
@@ -33,6 +31,30 @@ func (w *Writer) Float(v float64, bits int) bool {
 	}
 }
 
+func (w *Writer) floatES(v float64) bool {
+	switch s := w.stream; {
+	case s == nil:
+		w.Buf = floatAppendES(w.Buf, v)
+		return false
+	case s.fail():
+		return true
+	default:
+		tmp := make([]byte, 0, 32)
+		tmp = floatAppendES(tmp, v)
+		return writeStreamByteseq(w, tmp)
+	}
+}
+
+// floatAppendES appends finite v formatted like ECMAScript
+// Number.prototype.toString.
+func floatAppendES(b []byte, v float64) []byte {
+	if v == 0 {
+		// Negative zero is formatted as 0.
+		return append(b, '0')
+	}
+	return floatAppend(b, v, 64)
+}
+
 func floatAppend(b []byte, v float64, bits int) []byte {
 	// From go std sources, strconv/ftoa.go:
 
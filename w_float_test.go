@@ -0,0 +1,208 @@
+package jx
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_SetFloatFormat(t *testing.T) {
+	// Expected values are from Number.prototype.toString of node.
+	for i, tt := range []struct {
+		Value    float64
+		Expected string
+	}{
+		{0, "0"},
+		{math.Copysign(0, -1), "0"},
+		{1, "1"},
+		{-1, "-1"},
+		{0.1, "0.1"},
+		{0.30000000000000004, "0.30000000000000004"},
+		{100, "100"},
+		{1e20, "100000000000000000000"},
+		{123e18, "123000000000000000000"},
+		{1e21, "1e+21"},
+		{1.23e21, "1.23e+21"},
+		{999999999999999900000, "999999999999999900000"},
+		{1e-6, "0.000001"},
+		{1.2e-6, "0.0000012"},
+		{1e-7, "1e-7"},
+		{1.5e-7, "1.5e-7"},
+		{-5e-7, "-5e-7"},
+		{1e100, "1e+100"},
+		{-1e-100, "-1e-100"},
+		{5e-324, "5e-324"},
+		{math.MaxFloat64, "1.7976931348623157e+308"},
+		{9007199254740994, "9007199254740994"},
+		{123456789.123, "123456789.123"},
+		{1.0 / 3, "0.3333333333333333"},
+		{4.35, "4.35"},
+		{1 << 64, "18446744073709552000"},
+		{0.000123, "0.000123"},
+		{1e-5, "0.00001"},
+		{12345678901234567890, "12345678901234567000"},
+		{1e17, "100000000000000000"},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Test%d", i+1), func(t *testing.T) {
+			testEncoderModes(t, func(e *Encoder) {
+				e.SetFloatFormat(FloatES)
+				e.Float64(tt.Value)
+			}, tt.Expected)
+		})
+	}
+	t.Run("Float32", func(t *testing.T) {
+		for i, tt := range []struct {
+			Value    float32
+			Expected string
+		}{
+			{0.1, "0.10000000149011612"},
+			{16777216, "16777216"},
+			{math.MaxFloat32, "3.4028234663852886e+38"},
+			{math.SmallestNonzeroFloat32, "1.401298464324817e-45"},
+			{1e-7, "1.0000000116860974e-7"},
+			{3.14, "3.140000104904175"},
+		} {
+			tt := tt
+			t.Run(fmt.Sprintf("Test%d", i+1), func(t *testing.T) {
+				testEncoderModes(t, func(e *Encoder) {
+					e.SetFloatFormat(FloatES)
+					e.Float32(tt.Value)
+				}, tt.Expected)
+			})
+		}
+	})
+	t.Run("Default", func(t *testing.T) {
+		var w Writer
+		w.SetFloatFormat(FloatES)
+		w.SetFloatFormat(FloatDefault)
+		w.Float32(0.1)
+		w.Float64(math.Copysign(0, -1))
+		require.Equal(t, "0.1-0", w.String())
+	})
+	t.Run("Pool", func(t *testing.T) {
+		e := GetEncoder()
+		e.SetFloatFormat(FloatES)
+		PutEncoder(e)
+		w := GetWriter()
+		w.SetFloatFormat(FloatES)
+		PutWriter(w)
+
+		e = GetEncoder()
+		defer PutEncoder(e)
+		e.Float32(0.1)
+		require.Equal(t, "0.1", e.String())
+		w = GetWriter()
+		defer PutWriter(w)
+		w.Float32(0.1)
+		require.Equal(t, "0.1", w.String())
+	})
+}
+
+func TestWriter_SetFloatFormat_Corpus(t *testing.T) {
+	// Pairs of float64 bits in hex and result of Number.prototype.toString
+	// of node.
+	data, err := os.ReadFile(filepath.Join("testdata", "es_floats.json"))
+	require.NoError(t, err)
+
+	var (
+		w Writer
+		n int
+	)
+	w.SetFloatFormat(FloatES)
+	require.NoError(t, DecodeBytes(data).Arr(func(d *Decoder) error {
+		var pair []string
+		if err := d.Arr(func(d *Decoder) error {
+			s, err := d.Str()
+			pair = append(pair, s)
+			return err
+		}); err != nil {
+			return err
+		}
+		require.Len(t, pair, 2)
+		bits, err := strconv.ParseUint(pair[0], 16, 64)
+		require.NoError(t, err)
+
+		w.Reset()
+		w.Float64(math.Float64frombits(bits))
+		require.Equal(t, pair[1], w.String(), pair[0])
+		n++
+		return nil
+	}))
+	require.Equal(t, 1000, n)
+}
+
+func TestWriter_SetNonFiniteMode(t *testing.T) {
+	values := []float64{math.NaN(), math.Inf(1), math.Inf(-1)}
+	for _, tt := range []struct {
+		Name     string
+		Mode     NonFiniteMode
+		Expected string
+	}{
+		{"Null", NonFiniteNull, `[null,null,null,1.5]`},
+		{"String", NonFiniteString, `["NaN","Infinity","-Infinity",1.5]`},
+		{"Literal", NonFiniteLiteral, `[NaN,Infinity,-Infinity,1.5]`},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			testEncoderModes(t, func(e *Encoder) {
+				e.SetNonFiniteMode(tt.Mode)
+				e.Arr(func(e *Encoder) {
+					for _, v := range values {
+						require.False(t, e.Float64(v))
+					}
+					e.Float32(1.5)
+				})
+				require.NoError(t, e.Err())
+			}, tt.Expected)
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		var w Writer
+		w.SetNonFiniteMode(NonFiniteError)
+		require.False(t, w.Float64(1))
+		require.True(t, w.Float32(float32(math.Inf(-1))))
+		require.True(t, w.Float64(math.NaN()))
+		require.Equal(t, "1", w.String())
+		require.ErrorIs(t, w.Err(), ErrNonFinite)
+		require.EqualError(t, w.Err(), "-Infinity: non-finite float")
+
+		w.Reset()
+		require.NoError(t, w.Err())
+
+		e := GetEncoder()
+		defer PutEncoder(e)
+		e.SetNonFiniteMode(NonFiniteError)
+		require.True(t, e.Float64(math.Inf(1)))
+		require.ErrorIs(t, e.Err(), ErrNonFinite)
+	})
+	t.Run("ErrorSeparator", func(t *testing.T) {
+		testEncoderModes(t, func(e *Encoder) {
+			e.SetNonFiniteMode(NonFiniteError)
+			e.Arr(func(e *Encoder) {
+				e.Int(1)
+				require.True(t, e.Float64(math.NaN()))
+				require.True(t, e.Float32(float32(math.Inf(1))))
+			})
+			e.Obj(func(e *Encoder) {
+				e.Field("a", func(e *Encoder) { e.Float64(1) })
+			})
+			require.ErrorIs(t, e.Err(), ErrNonFinite)
+		}, `[1]{"a":1}`)
+	})
+	t.Run("Pool", func(t *testing.T) {
+		w := GetWriter()
+		w.SetNonFiniteMode(NonFiniteString)
+		PutWriter(w)
+
+		w = GetWriter()
+		defer PutWriter(w)
+		w.Float64(math.NaN())
+		require.Equal(t, "null", w.String())
+	})
+}
@@ -0,0 +1,15 @@
+package jx
+
+// seqRS is record separator of RFC 7464 json text sequence.
+const seqRS = 0x1E
+
+// SeqStart writes record separator that starts RFC 7464 json text sequence
+// record.
+func (w *Writer) SeqStart() bool {
+	return w.byte(seqRS)
+}
+
+// SeqEnd writes line feed that ends json text sequence record.
+func (w *Writer) SeqEnd() bool {
+	return w.byte('\n')
+}
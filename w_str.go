@@ -42,18 +42,19 @@ func writeStr[S byteseq.Byteseq](w *Writer, v S) (fail bool) {
 	fail = w.byte('"')
 
 	// Fast path, without utf8 and escape support.
-	var (
-		i      = 0
-		length = len(v)
-	)
-	for ; i < length && !fail; i++ {
-		c := v[i]
-		if safeSet[c] != 0 {
+	//
+	// Most strings are short, so check first bytes before vectorized scan.
+	i := 0
+	for ; i < len(v) && i < 16 && !fail; i++ {
+		if safeSet[v[i]] != 0 {
 			break
 		}
 	}
+	if i == 16 {
+		i += scanStr(v[i:])
+	}
 	fail = fail || writeStreamByteseq(w, v[:i])
-	if i == length {
+	if i == len(v) {
 		return fail || w.byte('"')
 	}
 	return fail || strSlow[S](w, v[i:])
@@ -65,7 +66,7 @@ func strSlow[S byteseq.Byteseq](w *Writer, v S) (fail bool) {
 	for i < len(v) && !fail {
 		b := v[i]
 		if safeSet[b] == 0 {
-			i++
+			i += scanStr(v[i:])
 			continue
 		}
 		if start < i {
@@ -126,16 +126,21 @@ func strEscape[S byteseq.Byteseq](w *Writer, v S) (fail bool) {
 	fail = w.byte('"')
 
 	// Fast path, probably does not require escaping.
+	//
+	// Most strings are short, so check first bytes before vectorized scan.
 	var (
 		i      = 0
 		length = len(v)
 	)
-	for ; i < length && !fail; i++ {
+	for ; i < length && i < 16 && !fail; i++ {
 		c := v[i]
 		if c >= utf8.RuneSelf || !(htmlSafeSet[c]) {
 			break
 		}
 	}
+	if i == 16 {
+		i += scanEscape(v[i:])
+	}
 	fail = fail || writeStreamByteseq(w, v[:i])
 	if i == length {
 		return fail || w.byte('"')
@@ -150,6 +155,10 @@ func strEscapeSlow[S byteseq.Byteseq](w *Writer, i int, v S, valLen int) (fail b
 		if b := v[i]; b < utf8.RuneSelf {
 			if htmlSafeSet[b] {
 				i++
+				// Skip run of safe bytes, if any.
+				if i+1 < valLen && v[i] < utf8.RuneSelf && htmlSafeSet[v[i]] {
+					i += scanEscape(v[i:])
+				}
 				continue
 			}
 			if start < i {